@@ -51,6 +51,8 @@ MALLOC_ASSIGN = re.compile(
 )
 FOPEN_ASSIGN = re.compile(rf"\b(?:FILE\s*\*\s*|auto\s+)({IDENT})\s*=\s*fopen\s*\(")
 FOPEN_REASSIGN = re.compile(rf"\b({IDENT})\s*=\s*fopen\s*\(")
+CGO_IMPORT = re.compile(r'^import\s+"C"\s*(?://.*)?$', re.MULTILINE)
+CGO_ALLOC = re.compile(rf"\b({IDENT})\s*:?=\s*C\.(?:CString|CBytes|malloc|calloc)\s*\(")
 
 
 def is_ignored(path: Path, root: Path) -> bool:
@@ -77,6 +79,53 @@ def iter_cpp_files(root: Path):
         yield path
 
 
+def iter_cgo_files(root: Path):
+    if root.is_file():
+        if root.suffix == ".go" and not is_ignored(root, root):
+            yield root
+        return
+    for path in root.rglob("*.go"):
+        if path.is_file() and not is_ignored(path, root):
+            yield path
+
+
+def cgo_preamble(text: str) -> str | None:
+    """Return the C preamble of a cgo file, line-aligned with the Go source.
+
+    The preamble is the comment directly above `import "C"`; every other line is
+    blanked so offsets map back to the original .go file.
+    """
+    match = CGO_IMPORT.search(text)
+    if not match:
+        return None
+    lines = text.splitlines()
+    import_idx = text.count("\n", 0, match.start())
+    kept: dict[int, str] = {}
+    idx = import_idx - 1
+    if idx >= 0 and lines[idx].rstrip().endswith("*/"):
+        end = idx
+        while idx >= 0 and "/*" not in lines[idx]:
+            idx -= 1
+        if idx < 0:
+            return None
+        for n in range(idx, end + 1):
+            line = lines[n]
+            if n == idx:
+                line = " " * (line.index("/*") + 2) + line[line.index("/*") + 2:]
+            if n == end:
+                cut = line.rindex("*/")
+                line = line[:cut]
+            kept[n] = line
+    else:
+        while idx >= 0 and lines[idx].lstrip().startswith("//"):
+            line = lines[idx]
+            kept[idx] = " " * (line.index("//") + 2) + line[line.index("//") + 2:]
+            idx -= 1
+    if not kept:
+        return None
+    return "\n".join(kept.get(n, "") for n in range(len(lines)))
+
+
 def strip_comments_and_strings(text: str) -> str:
     result: list[str] = []
     i = 0
@@ -180,7 +229,14 @@ def has_thread_release(name: str, code: str, start: int) -> bool:
 
 
 def has_c_release(name: str, func: str, code: str, start: int) -> bool:
-    return re.search(rf"\b{func}\s*\(\s*{re.escape(name)}\b", code[start:]) is not None
+    member = r"(?:[A-Za-z_][A-Za-z0-9_\[\]]*\s*(?:->|\.)\s*)*"
+    return re.search(rf"\b{func}\s*\(\s*(?:\([^)]*\)\s*)?{member}{re.escape(name)}\b", code[start:]) is not None
+
+
+def escapes_scope(name: str, code: str, start: int) -> bool:
+    """Ownership handed to the caller (returned or stored elsewhere)."""
+    n = re.escape(name)
+    return re.search(rf"\breturn\s+\(?\s*{n}\s*\)?\s*;|[^=!<>]=\s*{n}\s*;", code[start:]) is not None
 
 
 def is_thread_function_decl(name: str, text: str, pos: int) -> bool:
@@ -191,11 +247,34 @@ def collect_issues(root: Path) -> list[tuple[str, str, str]]:
     issues: list[tuple[str, str, str]] = []
     base = root if root.is_dir() else root.parent
 
+    sources: list[tuple[Path, str]] = []
     for path in iter_cpp_files(root):
         try:
             text = path.read_text(encoding="utf-8", errors="ignore")
         except OSError:
             continue
+        sources.append((path, text))
+    for path in iter_cgo_files(root):
+        try:
+            go_text = path.read_text(encoding="utf-8", errors="ignore")
+        except OSError:
+            continue
+        preamble = cgo_preamble(go_text)
+        if preamble is None:
+            continue
+        sources.append((path, preamble))
+        go_code = strip_comments_and_strings(go_text)
+        for match in CGO_ALLOC.finditer(go_code):
+            name = match.group(1)
+            if name == "_" or re.search(rf"\bC\.free\s*\(\s*(?:unsafe\.Pointer\s*\(\s*)?{re.escape(name)}\b", go_code[match.end():]):
+                continue
+            issues.append((
+                format_location(base, path, match.start(), go_text),
+                "cgo_alloc",
+                f"C memory from C.CString/C.CBytes/C.malloc is never released with C.free ({name})",
+            ))
+
+    for path, text in sources:
         if not text.strip():
             continue
         code = strip_comments_and_strings(text)
@@ -219,7 +298,7 @@ def collect_issues(root: Path) -> list[tuple[str, str, str]]:
 
         for match in MALLOC_ASSIGN.finditer(code):
             name = match.group(1)
-            if name == "_" or has_c_release(name, "free", code, match.end()):
+            if name == "_" or has_c_release(name, "free", code, match.end()) or escapes_scope(name, code, match.end()):
                 continue
             issue = (
                 format_location(base, path, match.start(), text),
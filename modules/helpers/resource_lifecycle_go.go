@@ -1,15 +1,71 @@
+// Command resource_lifecycle_go walks a Go project and reports resource
+// acquisitions (contexts, tickers, files, DB handles, locks) that are never
+// released. Each finding is printed as "file:line<TAB>kind<TAB>message".
+//
+// Exit codes:
+//
+//	0  no findings at or above -fail-on
+//	1  findings at or above -fail-on
+//	2  usage error
+//	3  internal error or one or more files could not be parsed
+//
+// With -summary json a single JSON object with per-severity, per-rule, and
+// parse-failure details is printed after the findings.
+//
+// Editors and daemons re-check an edit with
+//
+//	go run resource_lifecycle_go.go -range START:END path/to/file.go
+//
+// START:END (or a single line) is 1-based and inclusive, and the argument
+// must be a single .go file. Only the top-level functions overlapping those
+// lines are re-analyzed (see ScanRange), so a re-check costs one function, not
+// the whole file. Findings and exit codes are as for a full scan, with paths
+// relative to the file's directory. This command line is the supported entry
+// point: the helper is a single-file command, so ScanRange is not importable.
+//
+// stderr carries diagnostics only. -verbose logs each file with its finding
+// count and timing plus every skipped path with the reason; otherwise a
+// progress bar is redrawn while stderr is a terminal (-progress). -quiet
+// drops everything but errors: parse failures are still printed (callers
+// parse those "parse error: " lines) and still set the exit code.
+//
+// Files are streamed from the directory walk to a pool of -workers analyzers
+// and findings are printed in walk order as soon as each file is done, so
+// nothing proportional to the repository size is held in memory. Each file's
+// AST lives only while its worker analyzes it. -memory-limit SIZE caps the
+// heap: it becomes the runtime's soft memory limit, shrinks the pool to what
+// the limit can feed, and makes workers wait while the files already in flight
+// use up the limit (a file is assumed to need about astBytesPerByte times its
+// size).
+//
+// Finding messages are text/template strings looked up by message id in a
+// catalog (see defaultMessages). -messages FILE layers a JSON catalog on top
+// of the built-in English text and may be repeated; -locale selects which of
+// those files apply, so a team or rule pack can ship translated or reworded
+// templates without touching this file.
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"go/ast"
 	"go/parser"
+	"go/scanner"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 )
 
 type resourceKind string
@@ -22,15 +78,109 @@ const (
 	kindDB       resourceKind = "db_handle"
 	kindListener resourceKind = "listener_close"
 	kindMutex    resourceKind = "mutex_lock"
+	kindWrapper  resourceKind = "wrapper_close"
+	// kindView marks wrappers with nothing of their own to release (bufio,
+	// io.NopCloser). They are never reported; they only explain leak messages.
+	kindView resourceKind = "reader_view"
 )
 
+// wrapperSpec describes a constructor that wraps another reader, writer, or
+// listener passed as its first argument.
+type wrapperSpec struct {
+	kind        resourceKind
+	closesInner bool
+}
+
+var wrapperSpecs = map[string]wrapperSpec{
+	"gzip.NewReader":        {kindWrapper, false},
+	"gzip.NewWriter":        {kindWrapper, false},
+	"gzip.NewWriterLevel":   {kindWrapper, false},
+	"zlib.NewReader":        {kindWrapper, false},
+	"zlib.NewWriter":        {kindWrapper, false},
+	"zlib.NewWriterLevel":   {kindWrapper, false},
+	"flate.NewReader":       {kindWrapper, false},
+	"flate.NewWriter":       {kindWrapper, false},
+	"lzw.NewReader":         {kindWrapper, false},
+	"lzw.NewWriter":         {kindWrapper, false},
+	"bufio.NewReader":       {kindView, false},
+	"bufio.NewReaderSize":   {kindView, false},
+	"bufio.NewWriter":       {kindView, false},
+	"bufio.NewWriterSize":   {kindView, false},
+	"bufio.NewScanner":      {kindView, false},
+	"io.NopCloser":          {kindView, false},
+	"ioutil.NopCloser":      {kindView, false},
+	"io.LimitReader":        {kindView, false},
+	"tls.NewListener":       {kindListener, true},
+	"netutil.LimitListener": {kindListener, true},
+}
+
+const (
+	exitClean    = 0
+	exitFindings = 1
+	exitUsage    = 2
+	exitInternal = 3
+)
+
+// kindSeverity mirrors RESOURCE_LIFECYCLE_SEVERITY in ubs-golang.sh.
+var kindSeverity = map[resourceKind]string{
+	kindContext:  "critical",
+	kindTicker:   "warning",
+	kindTimer:    "warning",
+	kindFile:     "warning",
+	kindDB:       "warning",
+	kindListener: "warning",
+	kindMutex:    "warning",
+	kindWrapper:  "warning",
+}
+
+var severityRank = map[string]int{"info": 0, "warning": 1, "critical": 2}
+
+func severityOf(kind resourceKind) string {
+	if sev, ok := kindSeverity[kind]; ok {
+		return sev
+	}
+	return "warning"
+}
+
 type resource struct {
 	name     string
 	kind     resourceKind
 	position token.Position
 	released bool
+	// Wrapper layers remember their constructor and the resource they wrap.
+	label       string
+	wraps       *resource
+	closesInner bool
+	// Path information for conditional-release reporting: the function and
+	// branch the resource was acquired in, the error variable assigned with it,
+	// every release seen, and the first exit that leaves it open.
+	frame      *funcFrame
+	path       []branchStep
+	errName    string
+	releases   []release
+	leakLine   int
+	leakOnExit bool
 }
 
+// branchStep is one arm of a control-flow statement (if/else, loop body, case
+// clause); a path is the list of arms enclosing a statement in its function.
+// Case clauses are recorded as arm i of their switch or select statement.
+type branchStep struct {
+	node   ast.Node
+	branch int
+}
+
+type release struct {
+	line     int
+	path     []branchStep
+	deferred bool
+}
+
+// funcFrame identifies the function (declaration or literal) being walked.
+// The id gives each frame its own address; pointers to distinct zero-size
+// values may compare equal.
+type funcFrame struct{ id int }
+
 type scope struct {
 	byName map[string][]*resource
 }
@@ -43,6 +193,10 @@ type analyzer struct {
 	fset       *token.FileSet
 	resources  []*resource
 	scopeStack []*scope
+	frame      *funcFrame
+	frames     int
+	path       []branchStep
+	deferDepth int
 }
 
 func newAnalyzer(fset *token.FileSet) *analyzer {
@@ -66,13 +220,185 @@ func (a *analyzer) popScope() {
 	}
 }
 
-func (a *analyzer) add(name string, kind resourceKind, pos token.Position) {
-	res := &resource{name: name, kind: kind, position: pos}
+func (a *analyzer) add(name string, kind resourceKind, pos token.Position) *resource {
+	res := &resource{name: name, kind: kind, position: pos, frame: a.frame, path: copyPath(a.path)}
 	a.resources = append(a.resources, res)
 	if name != "" {
 		s := a.currentScope()
 		s.byName[name] = append(s.byName[name], res)
 	}
+	return res
+}
+
+// addWrapper records a wrapping layer such as gz := gzip.NewReader(f) and
+// links it to the most recent resource bound to the wrapped argument.
+func (a *analyzer) addWrapper(name, label string, spec wrapperSpec, call *ast.CallExpr, pos token.Position) *resource {
+	res := a.add(name, spec.kind, pos)
+	res.label = label
+	res.closesInner = spec.closesInner
+	if len(call.Args) == 0 {
+		return res
+	}
+	if entries := a.lookup(exprName(call.Args[0])); len(entries) > 0 {
+		res.wraps = entries[len(entries)-1]
+	}
+	return res
+}
+
+// noteRelease marks res released at pos and records the branch path. The
+// release propagates through layers whose Close also closes the resource they
+// wrap (tls.NewListener); gzip, bufio, and io.NopCloser layers stop the chain.
+func (a *analyzer) noteRelease(res *resource, pos token.Pos, deferred bool) {
+	line := a.fset.Position(pos).Line
+	for res != nil {
+		res.released = true
+		res.releases = append(res.releases, release{line: line, path: copyPath(a.path), deferred: deferred || a.deferDepth > 0})
+		if !res.closesInner {
+			return
+		}
+		res = res.wraps
+	}
+}
+
+// enterFunc starts a new function frame with an empty branch path and returns
+// a func that restores the enclosing one.
+func (a *analyzer) enterFunc() func() {
+	frame, path, deferDepth := a.frame, a.path, a.deferDepth
+	a.frames++
+	a.frame, a.path, a.deferDepth = &funcFrame{id: a.frames}, nil, 0
+	return func() {
+		a.frame, a.path, a.deferDepth = frame, path, deferDepth
+	}
+}
+
+func (a *analyzer) walkBranch(node ast.Node, branch int, child ast.Node) {
+	a.path = append(a.path, branchStep{node: node, branch: branch})
+	ast.Walk(a, child)
+	a.path = a.path[:len(a.path)-1]
+}
+
+// walkClauses walks each case clause of a switch or select as one arm of it.
+func (a *analyzer) walkClauses(node ast.Node, body *ast.BlockStmt) {
+	if body == nil {
+		return
+	}
+	for i, clause := range body.List {
+		a.walkBranch(node, i, clause)
+	}
+}
+
+// checkExit records, for each resource of the current function that is still
+// open on this path, the first return (or fall-through end) that leaks it.
+func (a *analyzer) checkExit(pos token.Pos, implicit bool) {
+	for _, res := range a.resources {
+		if res.frame != a.frame || res.leakLine != 0 || !hasPrefix(a.path, res.path) {
+			continue
+		}
+		if a.coveredHere(res) || a.errGuarded(res) {
+			continue
+		}
+		res.leakLine, res.leakOnExit = a.fset.Position(pos).Line, implicit
+	}
+}
+
+// coveredHere reports whether a release already seen applies to the current
+// path: a deferred release, one on an enclosing branch, or one in every arm of
+// an exhaustive statement (if/else, switch with default, select) that encloses
+// the current path.
+func (a *analyzer) coveredHere(res *resource) bool {
+	for _, rel := range res.releases {
+		if rel.deferred || hasPrefix(a.path, rel.path) {
+			return true
+		}
+	}
+	for _, r1 := range res.releases {
+		for k, step := range r1.path {
+			if !hasPrefix(a.path, r1.path[:k]) {
+				break
+			}
+			arms := armCount(step.node)
+			if arms == 0 {
+				continue
+			}
+			covered := map[int]bool{}
+			for _, r2 := range res.releases {
+				if len(r2.path) > k && commonPrefix(r2.path, r1.path[:k]) == k && r2.path[k].node == step.node {
+					covered[r2.path[k].branch] = true
+				}
+			}
+			if len(covered) == arms {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// armCount is the number of arms that together cover every path through an
+// exhaustive statement, or 0 when some path can skip all of them: an if
+// without else, a switch without default, or a loop body.
+func armCount(node ast.Node) int {
+	switch n := node.(type) {
+	case *ast.IfStmt:
+		if n.Else != nil {
+			return 2
+		}
+	case *ast.SwitchStmt:
+		if hasDefault(n.Body) {
+			return len(n.Body.List)
+		}
+	case *ast.TypeSwitchStmt:
+		if hasDefault(n.Body) {
+			return len(n.Body.List)
+		}
+	case *ast.SelectStmt:
+		// select blocks until one of its cases runs, with or without default.
+		return len(n.Body.List)
+	}
+	return 0
+}
+
+func hasDefault(body *ast.BlockStmt) bool {
+	for _, stmt := range body.List {
+		if clause, ok := stmt.(*ast.CaseClause); ok && clause.List == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// errGuarded skips the `if err != nil { return }` right after an acquisition,
+// where the resource itself is nil.
+func (a *analyzer) errGuarded(res *resource) bool {
+	if res.errName == "" {
+		return false
+	}
+	for _, step := range a.path[len(res.path):] {
+		ifStmt, ok := step.node.(*ast.IfStmt)
+		if !ok || step.branch != 0 || a.fset.Position(ifStmt.Pos()).Line > res.position.Line+1 {
+			continue
+		}
+		if types.ExprString(ifStmt.Cond) == res.errName+" != nil" {
+			return true
+		}
+	}
+	return false
+}
+
+func copyPath(path []branchStep) []branchStep {
+	return append([]branchStep(nil), path...)
+}
+
+func hasPrefix(path, prefix []branchStep) bool {
+	return len(prefix) <= len(path) && commonPrefix(path, prefix) == len(prefix)
+}
+
+func commonPrefix(a, b []branchStep) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
 }
 
 func (a *analyzer) lookup(name string) []*resource {
@@ -86,22 +412,30 @@ func (a *analyzer) lookup(name string) []*resource {
 	return nil
 }
 
-func (a *analyzer) markReleased(name string, kinds ...resourceKind) {
+func (a *analyzer) markReleased(pos token.Pos, name string, kinds ...resourceKind) {
 	if name == "" {
 		return
 	}
 	entries := a.lookup(name)
 	// When a name is rebound (e.g., `f := os.Open(...); f = os.Open(...); f.Close()`),
 	// the close applies to the most recent acquisition bound to that identifier.
+	// A repeated release (e.g., in the else branch) is still recorded as a path.
+	var again *resource
 	for i := len(entries) - 1; i >= 0; i-- {
 		res := entries[i]
-		if res.released {
+		if len(kinds) != 0 && !containsKind(kinds, res.kind) {
 			continue
 		}
-		if len(kinds) == 0 || containsKind(kinds, res.kind) {
-			res.released = true
+		if !res.released {
+			a.noteRelease(res, pos, false)
 			return
 		}
+		if again == nil {
+			again = res
+		}
+	}
+	if again != nil {
+		a.noteRelease(again, pos, false)
 	}
 }
 
@@ -124,6 +458,7 @@ func (a *analyzer) Visit(node ast.Node) ast.Visitor {
 	// Scope-creating nodes: manual walk with push/pop
 	case *ast.FuncDecl:
 		a.pushScope()
+		restore := a.enterFunc()
 		if n.Recv != nil {
 			ast.Walk(a, n.Recv)
 		}
@@ -132,19 +467,40 @@ func (a *analyzer) Visit(node ast.Node) ast.Visitor {
 		}
 		if n.Body != nil {
 			ast.Walk(a, n.Body)
+			if !hasResults(n.Type) && !terminates(n.Body) {
+				a.checkExit(n.Body.Rbrace, true)
+			}
 		}
+		restore()
 		a.popScope()
 		return nil
 	case *ast.FuncLit:
 		a.pushScope()
+		restore := a.enterFunc()
 		if n.Type != nil {
 			ast.Walk(a, n.Type)
 		}
 		if n.Body != nil {
 			ast.Walk(a, n.Body)
+			if !hasResults(n.Type) && !terminates(n.Body) {
+				a.checkExit(n.Body.Rbrace, true)
+			}
 		}
+		restore()
 		a.popScope()
 		return nil
+	case *ast.DeferStmt:
+		a.deferDepth++
+		ast.Walk(a, n.Call)
+		a.deferDepth--
+		return nil
+	case *ast.GoStmt:
+		// Releases inside a goroutine happen on its own schedule; treat them
+		// like deferred ones rather than as part of this function's paths.
+		a.deferDepth++
+		ast.Walk(a, n.Call)
+		a.deferDepth--
+		return nil
 	case *ast.BlockStmt:
 		a.pushScope()
 		for _, stmt := range n.List {
@@ -161,10 +517,10 @@ func (a *analyzer) Visit(node ast.Node) ast.Visitor {
 			ast.Walk(a, n.Cond)
 		}
 		if n.Body != nil {
-			ast.Walk(a, n.Body)
+			a.walkBranch(n, 0, n.Body)
 		}
 		if n.Else != nil {
-			ast.Walk(a, n.Else)
+			a.walkBranch(n, 1, n.Else)
 		}
 		a.popScope()
 		return nil
@@ -180,7 +536,7 @@ func (a *analyzer) Visit(node ast.Node) ast.Visitor {
 			ast.Walk(a, n.Post)
 		}
 		if n.Body != nil {
-			ast.Walk(a, n.Body)
+			a.walkBranch(n, 0, n.Body)
 		}
 		a.popScope()
 		return nil
@@ -196,7 +552,7 @@ func (a *analyzer) Visit(node ast.Node) ast.Visitor {
 			ast.Walk(a, n.X)
 		}
 		if n.Body != nil {
-			ast.Walk(a, n.Body)
+			a.walkBranch(n, 0, n.Body)
 		}
 		a.popScope()
 		return nil
@@ -208,9 +564,7 @@ func (a *analyzer) Visit(node ast.Node) ast.Visitor {
 		if n.Tag != nil {
 			ast.Walk(a, n.Tag)
 		}
-		if n.Body != nil {
-			ast.Walk(a, n.Body)
-		}
+		a.walkClauses(n, n.Body)
 		a.popScope()
 		return nil
 	case *ast.TypeSwitchStmt:
@@ -221,16 +575,12 @@ func (a *analyzer) Visit(node ast.Node) ast.Visitor {
 		if n.Assign != nil {
 			ast.Walk(a, n.Assign)
 		}
-		if n.Body != nil {
-			ast.Walk(a, n.Body)
-		}
+		a.walkClauses(n, n.Body)
 		a.popScope()
 		return nil
 	case *ast.SelectStmt:
 		a.pushScope()
-		if n.Body != nil {
-			ast.Walk(a, n.Body)
-		}
+		a.walkClauses(n, n.Body)
 		a.popScope()
 		return nil
 	case *ast.CaseClause:
@@ -262,8 +612,13 @@ func (a *analyzer) Visit(node ast.Node) ast.Visitor {
 		a.handleCall(n)
 		return a
 	case *ast.ReturnStmt:
+		// Walk the results first so `return f.Close()` counts as a release.
+		for _, result := range n.Results {
+			ast.Walk(a, result)
+		}
 		a.handleReturn(n)
-		return a
+		a.checkExit(n.Pos(), false)
+		return nil
 	}
 
 	return a
@@ -272,12 +627,115 @@ func (a *analyzer) Visit(node ast.Node) ast.Visitor {
 func (a *analyzer) handleReturn(ret *ast.ReturnStmt) {
 	for _, res := range ret.Results {
 		if id, ok := res.(*ast.Ident); ok {
-			a.markReleasedAllScopes(id.Name)
+			a.markReleasedAllScopes(ret.Pos(), id.Name)
 		}
 	}
 }
 
-func (a *analyzer) markReleasedAllScopes(name string) {
+func hasResults(fn *ast.FuncType) bool {
+	return fn != nil && fn.Results != nil && len(fn.Results.List) > 0
+}
+
+// terminates reports whether stmt is a terminating statement in the sense of
+// the Go spec, so control never falls through past it: return, goto, panic, a
+// block or if/else ending in one, a for without condition or break, and a
+// switch/select without break whose every clause terminates.
+func terminates(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return s.Tok == token.GOTO
+	case *ast.ExprStmt:
+		call, ok := s.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		id, ok := call.Fun.(*ast.Ident)
+		return ok && id.Name == "panic"
+	case *ast.BlockStmt:
+		return len(s.List) > 0 && terminates(s.List[len(s.List)-1])
+	case *ast.IfStmt:
+		return s.Else != nil && terminates(s.Body) && terminates(s.Else)
+	case *ast.LabeledStmt:
+		return terminatesLabeled(s.Stmt, s.Label.Name)
+	case *ast.ForStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+		return terminatesLabeled(s, "")
+	}
+	return false
+}
+
+func terminatesLabeled(stmt ast.Stmt, label string) bool {
+	switch s := stmt.(type) {
+	case *ast.ForStmt:
+		return s.Cond == nil && !hasBreak(s.Body, label)
+	case *ast.SwitchStmt:
+		return hasDefault(s.Body) && clausesTerminate(s.Body, label)
+	case *ast.TypeSwitchStmt:
+		return hasDefault(s.Body) && clausesTerminate(s.Body, label)
+	case *ast.SelectStmt:
+		return clausesTerminate(s.Body, label)
+	}
+	return terminates(stmt)
+}
+
+func clausesTerminate(body *ast.BlockStmt, label string) bool {
+	for _, stmt := range body.List {
+		var list []ast.Stmt
+		switch clause := stmt.(type) {
+		case *ast.CaseClause:
+			list = clause.Body
+		case *ast.CommClause:
+			list = clause.Body
+		}
+		if hasBreak(&ast.BlockStmt{List: list}, label) || len(list) == 0 {
+			return false
+		}
+		last := list[len(list)-1]
+		if branch, ok := last.(*ast.BranchStmt); ok && branch.Tok == token.FALLTHROUGH {
+			continue
+		}
+		if !terminates(last) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasBreak reports whether body contains a break that leaves the enclosing
+// statement: an unlabeled break outside nested for/switch/select statements,
+// or a break naming label. Function literals are not searched.
+func hasBreak(body ast.Node, label string) bool {
+	found := false
+	var visit func(n ast.Node, nested bool)
+	visit = func(n ast.Node, nested bool) {
+		ast.Inspect(n, func(node ast.Node) bool {
+			if found || node == nil {
+				return false
+			}
+			switch b := node.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.BranchStmt:
+				if b.Tok == token.BREAK && ((b.Label == nil && !nested) || (b.Label != nil && b.Label.Name == label)) {
+					found = true
+				}
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				if node != n {
+					visit(node, true)
+					return false
+				}
+			}
+			return true
+		})
+	}
+	visit(body, false)
+	return found
+}
+
+// markReleasedAllScopes hands ownership to the caller (returned resources);
+// that covers every path, so it is recorded like a deferred release.
+func (a *analyzer) markReleasedAllScopes(pos token.Pos, name string) {
 	if name == "" {
 		return
 	}
@@ -286,7 +744,7 @@ func (a *analyzer) markReleasedAllScopes(name string) {
 		if entries, ok := scope.byName[name]; ok {
 			for _, res := range entries {
 				if !res.released {
-					res.released = true
+					a.noteRelease(res, pos, true)
 				}
 			}
 		}
@@ -303,6 +761,16 @@ func (a *analyzer) handleAssign(assign *ast.AssignStmt) {
 		if !ok {
 			return
 		}
+		if label, spec, ok := wrapperOf(call); ok {
+			names := collectNames(assign.Lhs)
+			if len(names) > 0 && names[0] != "" && names[0] != "_" {
+				res := a.addWrapper(names[0], label, spec, call, a.fset.Position(assign.Pos()))
+				if len(names) > 1 && names[len(names)-1] != "_" {
+					res.errName = names[len(names)-1]
+				}
+			}
+			return
+		}
 		kind := classifyCall(call)
 		if kind == "" {
 			return
@@ -325,7 +793,10 @@ func (a *analyzer) handleAssign(assign *ast.AssignStmt) {
 			if len(names) > 0 {
 				name := names[0]
 				if name != "" && name != "_" {
-					a.add(name, kind, pos)
+					res := a.add(name, kind, pos)
+					if len(names) > 1 && names[len(names)-1] != "_" {
+						res.errName = names[len(names)-1]
+					}
 				}
 			}
 		}
@@ -379,6 +850,16 @@ func classifyCall(call *ast.CallExpr) resourceKind {
 	}
 }
 
+func wrapperOf(call *ast.CallExpr) (string, wrapperSpec, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", wrapperSpec{}, false
+	}
+	label := exprName(sel.X) + "." + sel.Sel.Name
+	spec, ok := wrapperSpecs[label]
+	return label, spec, ok
+}
+
 func (a *analyzer) handleCall(call *ast.CallExpr) {
 	switch fun := call.Fun.(type) {
 	case *ast.SelectorExpr:
@@ -390,14 +871,14 @@ func (a *analyzer) handleCall(call *ast.CallExpr) {
 				a.add(base, kindMutex, a.fset.Position(call.Pos()))
 			}
 		case "Stop":
-			a.markReleased(base, kindTicker, kindTimer)
+			a.markReleased(call.Pos(), base, kindTicker, kindTimer)
 		case "Close":
-			a.markReleased(base, kindFile, kindDB, kindListener)
+			a.markReleased(call.Pos(), base, kindFile, kindDB, kindListener, kindWrapper, kindView)
 		case "Unlock":
-			a.markReleased(base, kindMutex)
+			a.markReleased(call.Pos(), base, kindMutex)
 		}
 	case *ast.Ident:
-		a.markReleased(fun.Name, kindContext)
+		a.markReleased(call.Pos(), fun.Name, kindContext)
 	}
 }
 
@@ -435,55 +916,348 @@ func collectNames(exprs []ast.Expr) []string {
 	return names
 }
 
-func analyzeFile(path, root string) ([]string, error) {
+type finding struct {
+	location string
+	kind     resourceKind
+	message  string
+}
+
+func (f finding) String() string {
+	return fmt.Sprintf("%s\t%s\t%s", f.location, f.kind, f.message)
+}
+
+func relPath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+// analyzeFile reports unreleased resources in path. A file with syntax errors
+// yields a parseFailure; when the parser recovered a partial AST it is still
+// walked, so leaks before (and often after) the broken construct are reported.
+func analyzeFile(path, root string) ([]finding, *parseFailure) {
+	return analyzeDecls(path, root, nil)
+}
+
+// ScanRange re-analyzes only the top-level functions of file that overlap
+// lines startLine..endLine (inclusive). Resources are tracked per function,
+// so the result for those functions matches a full-file scan. Finding paths
+// are relative to the file's directory.
+func ScanRange(file string, startLine, endLine int) ([]finding, *parseFailure) {
+	return analyzeDecls(file, filepath.Dir(file), func(fset *token.FileSet, decl ast.Decl) bool {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			return false
+		}
+		return fset.Position(fn.Pos()).Line <= endLine && fset.Position(fn.End()).Line >= startLine
+	})
+}
+
+// analyzeDecls walks the whole file when keep is nil, otherwise only the
+// top-level declarations keep selects.
+func analyzeDecls(path, root string, keep func(*token.FileSet, ast.Decl) bool) ([]finding, *parseFailure) {
+	rel := relPath(root, path)
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	var failure *parseFailure
 	if err != nil {
-		return nil, err
+		failure = newParseFailure(rel, err, file != nil)
+		if file == nil {
+			return nil, failure
+		}
 	}
 	visitor := newAnalyzer(fset)
-	ast.Walk(visitor, file)
+	if keep == nil {
+		ast.Walk(visitor, file)
+	} else {
+		for _, decl := range file.Decls {
+			if keep(fset, decl) {
+				ast.Walk(visitor, decl)
+			}
+		}
+	}
 
-	rel, err := filepath.Rel(root, path)
-	if err != nil {
-		rel = path
+	var issues []finding
+	outer := map[*resource][]*resource{}
+	for _, res := range visitor.resources {
+		if res.wraps != nil {
+			outer[res.wraps] = append(outer[res.wraps], res)
+		}
 	}
-	var issues []string
 	for _, res := range visitor.resources {
-		if res.released {
+		if res.kind == kindView {
 			continue
 		}
-		line := res.position.Line
-		location := fmt.Sprintf("%s:%d", rel, line)
-		message := formatMessage(res.kind, res.name)
-		issues = append(issues, fmt.Sprintf("%s\t%s\t%s", location, res.kind, message))
+		message := formatMessage(res.kind, res.name) + layerNote(res, outer[res])
+		if res.released {
+			if res.leakLine == 0 {
+				continue
+			}
+			message = pathMessage(res)
+		}
+		issues = append(issues, finding{
+			location: fmt.Sprintf("%s:%d", rel, res.position.Line),
+			kind:     res.kind,
+			message:  message,
+		})
 	}
-	return issues, nil
+	return issues, failure
 }
 
-func formatMessage(kind resourceKind, name string) string {
-	subject := name
-	if subject == "" {
-		subject = "resource"
+// layerNote names the wrapper layers around an unreleased resource so the
+// report says which layer is still open and why closing the outer one did
+// not help.
+func layerNote(res *resource, outers []*resource) string {
+	var notes []string
+	if res.wraps != nil && res.wraps.name != "" {
+		notes = append(notes, messages.render("lifecycle.layer_wraps", messageData{Wrapper: res.label, Inner: res.wraps.name}))
+	}
+	for _, layer := range outers {
+		data := messageData{Subject: nameOr(res.name), Outer: layer.name, Wrapper: layer.label}
+		if layer.released {
+			notes = append(notes, messages.render("lifecycle.layer_closed", data))
+		} else {
+			notes = append(notes, messages.render("lifecycle.layer_open", data))
+		}
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(notes, "; ") + ")"
+}
+
+// pathMessage explains a resource released on some paths but not others:
+// which branch releases it, which exit leaks it, and the defer that fixes it.
+func pathMessage(res *resource) string {
+	first := res.releases[0]
+	data := resourceData(res.kind, res.name)
+	released := messageData{Line: first.line, Condition: branchCondition(first.path[min(len(res.path), len(first.path)):])}
+	if released.Condition != "" {
+		data.Released = messages.render("lifecycle.released_when", released)
+	} else {
+		data.Released = messages.render("lifecycle.released_at", released)
 	}
+	leaked := "lifecycle.leaked_return"
+	if res.leakOnExit {
+		leaked = "lifecycle.leaked_exit"
+	}
+	data.Leaked = messages.render(leaked, messageData{Line: res.leakLine})
+	data.Line = res.position.Line
+	return messages.render("lifecycle.partial_release", data)
+}
+
+// branchCondition renders the if/else arms of a path as a condition.
+func branchCondition(path []branchStep) string {
+	var conds []string
+	for _, step := range path {
+		ifStmt, ok := step.node.(*ast.IfStmt)
+		if !ok {
+			continue
+		}
+		cond := types.ExprString(ifStmt.Cond)
+		if step.branch == 1 {
+			cond = "!(" + cond + ")"
+		}
+		conds = append(conds, cond)
+	}
+	return strings.Join(conds, " && ")
+}
+
+// resourceData fills the template fields shared by every message about one
+// resource: its name, kind, rule id, label, and the call that releases it.
+func resourceData(kind resourceKind, name string) messageData {
+	data := messageData{Name: name, Subject: nameOr(name), Kind: string(kind), Rule: "go.resource." + string(kind),
+		Release: releaseCall(kind, name)}
+	data.Label = messages.render(data.Rule+".label", data)
+	return data
+}
+
+func releaseCall(kind resourceKind, name string) string {
+	subject := nameOr(name)
 	switch kind {
 	case kindContext:
-		return "context.With* cancel function never invoked"
-	case kindTicker:
-		return fmt.Sprintf("Ticker %s missing Stop()", subject)
-	case kindTimer:
-		return fmt.Sprintf("Timer %s missing Stop()", subject)
-	case kindFile:
-		return fmt.Sprintf("File handle %s opened without Close()", subject)
-	case kindDB:
-		return fmt.Sprintf("DB handle %s opened without Close()", subject)
-	case kindListener:
-		return fmt.Sprintf("Listener %s opened without Close()", subject)
+		return subject + "()"
+	case kindTicker, kindTimer:
+		return subject + ".Stop()"
 	case kindMutex:
-		return fmt.Sprintf("Mutex %s locked without Unlock()", subject)
+		return subject + ".Unlock()"
 	default:
-		return "Resource not released"
+		return subject + ".Close()"
+	}
+}
+
+func nameOr(name string) string {
+	if name == "" {
+		return "resource"
+	}
+	return name
+}
+
+func formatMessage(kind resourceKind, name string) string {
+	data := resourceData(kind, name)
+	return messages.render(data.Rule, data)
+}
+
+// defaultMessages is the built-in English catalog. The unreleased-resource
+// message for a kind is keyed by its rule id (go.resource.<kind>) and its
+// label by the rule id plus ".label"; kinds without an entry fall back to
+// go.resource / go.resource.label.
+var defaultMessages = map[string]string{
+	"go.resource":                      "Resource not released",
+	"go.resource.context_cancel":       "context.With* cancel function never invoked",
+	"go.resource.ticker_stop":          "Ticker {{.Subject}} missing Stop()",
+	"go.resource.timer_stop":           "Timer {{.Subject}} missing Stop()",
+	"go.resource.file_handle":          "File handle {{.Subject}} opened without Close()",
+	"go.resource.db_handle":            "DB handle {{.Subject}} opened without Close()",
+	"go.resource.listener_close":       "Listener {{.Subject}} opened without Close()",
+	"go.resource.mutex_lock":           "Mutex {{.Subject}} locked without Unlock()",
+	"go.resource.wrapper_close":        "Wrapper {{.Subject}} missing Close()",
+	"go.resource.label":                "Resource {{.Subject}}",
+	"go.resource.context_cancel.label": "context cancel func {{.Subject}}",
+	"go.resource.ticker_stop.label":    "Ticker {{.Subject}}",
+	"go.resource.timer_stop.label":     "Timer {{.Subject}}",
+	"go.resource.file_handle.label":    "File handle {{.Subject}}",
+	"go.resource.db_handle.label":      "DB handle {{.Subject}}",
+	"go.resource.listener_close.label": "Listener {{.Subject}}",
+	"go.resource.mutex_lock.label":     "Mutex {{.Subject}}",
+	"go.resource.wrapper_close.label":  "Wrapper {{.Subject}}",
+	"lifecycle.partial_release":        "{{.Label}} released on some paths only ({{.Released}}, {{.Leaked}}); move {{.Release}} into a defer immediately after acquisition",
+	"lifecycle.released_at":            "released at line {{.Line}}",
+	"lifecycle.released_when":          "released at line {{.Line}} when {{.Condition}}",
+	"lifecycle.leaked_return":          "leaked on early return at line {{.Line}}",
+	"lifecycle.leaked_exit":            "leaked when the function ends at line {{.Line}}",
+	"lifecycle.layer_wraps":            "{{.Wrapper}} wraps {{.Inner}}",
+	"lifecycle.layer_closed":           "closing {{.Outer}} ({{.Wrapper}}) does not close {{.Subject}}",
+	"lifecycle.layer_open":             "wrapped by {{.Outer}} ({{.Wrapper}})",
+}
+
+// messageData is what a template can reference. Which fields are set depends
+// on the message: resource messages carry Name through Release, partial
+// releases add Line/Released/Leaked, and layer notes use Wrapper/Inner/Outer.
+type messageData struct {
+	Name      string // variable name, empty when the result was not assigned
+	Subject   string // Name, or "resource" when there is none
+	Kind      string // resource kind, e.g. file_handle
+	Rule      string // rule id, e.g. go.resource.file_handle
+	Label     string // rendered go.resource.<kind>.label
+	Release   string // suggested release call, e.g. f.Close()
+	Line      int
+	Condition string
+	Released  string
+	Leaked    string
+	Wrapper   string
+	Inner     string
+	Outer     string
+}
+
+var sampleMessageData = messageData{Name: "f", Subject: "f", Kind: string(kindFile), Rule: "go.resource.file_handle",
+	Label: "File handle f", Release: "f.Close()", Line: 12, Condition: "err != nil",
+	Released: "released at line 14", Leaked: "leaked on early return at line 12",
+	Wrapper: "gzip.NewReader", Inner: "f", Outer: "gz"}
+
+type catalog struct {
+	templates map[string]*template.Template
+}
+
+var messages = mustCatalog(defaultMessages)
+
+func mustCatalog(entries map[string]string) *catalog {
+	c := &catalog{templates: map[string]*template.Template{}}
+	for id, text := range entries {
+		if err := c.set(id, text); err != nil {
+			panic(err)
+		}
+	}
+	return c
+}
+
+// set parses text and renders it once against sample data, so unknown fields
+// and syntax errors surface when a catalog is loaded rather than mid-report.
+func (c *catalog) set(id, text string) error {
+	tmpl, err := template.New(id).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(new(strings.Builder), sampleMessageData); err != nil {
+		return err
+	}
+	c.templates[id] = tmpl
+	return nil
+}
+
+// render executes the template for id, falling back from a kind-specific id
+// (go.resource.<kind>[.label]) to the generic one.
+func (c *catalog) render(id string, data messageData) string {
+	tmpl, ok := c.templates[id]
+	if !ok && data.Rule != "" && strings.HasPrefix(id, data.Rule) {
+		tmpl, ok = c.templates["go.resource"+strings.TrimPrefix(id, data.Rule)]
+	}
+	if !ok {
+		return id
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return id
+	}
+	return out.String()
+}
+
+// catalogFile is the JSON read by -messages: templates keyed by message id,
+// limited to one locale ("de", "pt-BR") or, without "locale", applied to all.
+type catalogFile struct {
+	Locale   string            `json:"locale"`
+	Messages map[string]string `json:"messages"`
+}
+
+// normalizeLocale turns "de_DE.UTF-8" or "de-DE" into "de-de".
+func normalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// localeMatches reports whether a catalog written for have applies to want;
+// a language-only catalog ("de") also covers regional locales ("de-at").
+func localeMatches(have, want string) bool {
+	have = normalizeLocale(have)
+	if want == "" {
+		want = "en"
 	}
+	return have == "" || have == want || strings.HasPrefix(want, have+"-")
+}
+
+// load layers the entries of a catalog file over the current templates when
+// its locale matches. Ids the helper never renders are returned so the caller
+// can warn about typos.
+func (c *catalog) load(path, locale string) (applied bool, unknown []string, err error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return false, nil, err
+	}
+	var file catalogFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return false, nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if !localeMatches(file.Locale, normalizeLocale(locale)) {
+		return false, nil, nil
+	}
+	ids := make([]string, 0, len(file.Messages))
+	for id := range file.Messages {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, known := defaultMessages[id]; !known {
+			unknown = append(unknown, id)
+			continue
+		}
+		if err := c.set(id, file.Messages[id]); err != nil {
+			return false, nil, fmt.Errorf("%s: message %q: %v", path, id, err)
+		}
+	}
+	return true, unknown, nil
 }
 
 var ignoreDirs = map[string]struct{}{
@@ -496,55 +1270,523 @@ var ignoreDirs = map[string]struct{}{
 	"bin":         {},
 }
 
-func collectGoFiles(root string) ([]string, error) {
-	files := []string{}
-	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+// skippedPath is a file or directory the walk did not analyze.
+type skippedPath struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// walkGoFiles calls visit for every .go file under root in lexical walk order
+// without collecting them first; skip receives the paths left out.
+func walkGoFiles(root string, visit func(path string, size int64), skip func(skippedPath)) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 		if d.IsDir() {
-			if _, skip := ignoreDirs[d.Name()]; skip {
+			if _, ignored := ignoreDirs[d.Name()]; ignored {
+				skip(skippedPath{relPath(root, path) + "/", "ignored directory"})
 				return filepath.SkipDir
 			}
 			return nil
 		}
+		if d.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				skip(skippedPath{relPath(root, path) + "/", "symlinked directory not followed"})
+				return nil
+			}
+		}
 		if strings.HasSuffix(d.Name(), ".go") {
-			files = append(files, path)
+			var size int64
+			if info, err := d.Info(); err == nil {
+				size = info.Size()
+			}
+			visit(path, size)
 		}
 		return nil
 	})
+}
+
+// astBytesPerByte estimates the heap a file needs while it is parsed and
+// walked, as a multiple of its size on disk; workerFloor is the least a
+// worker is budgeted so a tight -memory-limit does not start more workers
+// than it can feed. minMemoryLimit rejects limits too small to analyze
+// anything, which in practice are a forgotten unit (-memory-limit=512).
+const (
+	astBytesPerByte = 24
+	workerFloor     = 32 << 20
+	minMemoryLimit  = 16 << 20
+)
+
+var byteSizeRE = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(?:([kmgt])(?:i?b)?|b)$`)
+
+// parseByteSize accepts a size with a binary unit: 512M, 1.5GiB, 800mb, 2g.
+// A bare number is rejected rather than read as bytes; 0 means no limit.
+func parseByteSize(value string) (int64, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == "0" {
+		return 0, nil
+	}
+	m := byteSizeRE.FindStringSubmatch(normalized)
+	if m == nil {
+		return 0, fmt.Errorf("invalid -memory-limit %q (want a size with a unit such as 512M or 2GiB; 0 means no limit)", value)
+	}
+	amount, _ := strconv.ParseFloat(m[1], 64)
+	shift := strings.Index("kmgt", m[2]) + 1
+	if m[2] == "" {
+		shift = 0
+	}
+	size := int64(amount * float64(uint64(1)<<(10*shift)))
+	if size > 0 && size < minMemoryLimit {
+		return 0, fmt.Errorf("invalid -memory-limit %q: below the 16MiB minimum", value)
+	}
+	return size, nil
+}
+
+// memoryBudget hands out the -memory-limit to workers by estimated file
+// cost. A file that alone exceeds the budget waits until it runs by itself.
+type memoryBudget struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	limit int64
+	used  int64
+}
+
+func newMemoryBudget(limit int64) *memoryBudget {
+	b := &memoryBudget{limit: limit}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+func (b *memoryBudget) acquire(cost int64) int64 {
+	if b.limit <= 0 {
+		return 0
+	}
+	cost = min(cost, b.limit)
+	b.mu.Lock()
+	for b.used > 0 && b.used+cost > b.limit {
+		b.cond.Wait()
+	}
+	b.used += cost
+	b.mu.Unlock()
+	return cost
+}
+
+func (b *memoryBudget) release(cost int64) {
+	if cost == 0 {
+		return
+	}
+	b.mu.Lock()
+	b.used -= cost
+	b.mu.Unlock()
+	b.cond.Broadcast()
+}
+
+// poolSize is the worker count for -workers and -memory-limit: what was
+// asked for (default GOMAXPROCS), cut to the workers the limit can feed.
+func poolSize(workers int, limit int64) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if limit > 0 {
+		workers = min(workers, max(1, int(limit/workerFloor)))
+	}
+	return workers
+}
+
+// scanJob is one file from the walk; seq is its position in walk order.
+type scanJob struct {
+	seq  int
+	path string
+	size int64
+}
+
+// scanResult carries one of: a finished file, a path the walk skipped
+// (skipped != nil), or the end of the walk (walked, with seq holding the
+// number of files and err any walk error).
+type scanResult struct {
+	scanJob
+	issues  []finding
+	failure *parseFailure
+	elapsed time.Duration
+	skipped *skippedPath
+	walked  bool
+	err     error
+}
+
+// scanFiles runs the pipeline: walk (or the single -range file) -> workers ->
+// results. The walker stops handing out files while window results are
+// waiting to be printed in order, which bounds memory when one slow file
+// holds up the output; the reader frees a slot per file it prints.
+func scanFiles(walk func(visit func(string, int64), skip func(skippedPath)) error,
+	analyze func(path string) ([]finding, *parseFailure), workers int, budget *memoryBudget,
+	window chan struct{}) <-chan scanResult {
+	jobs := make(chan scanJob)
+	results := make(chan scanResult, workers)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				started := time.Now()
+				reserved := budget.acquire(job.size * astBytesPerByte)
+				issues, failure := analyze(job.path)
+				budget.release(reserved)
+				results <- scanResult{scanJob: job, issues: issues, failure: failure, elapsed: time.Since(started)}
+			}
+		}()
+	}
+	go func() {
+		seq := 0
+		err := walk(func(path string, size int64) {
+			window <- struct{}{}
+			jobs <- scanJob{seq: seq, path: path, size: size}
+			seq++
+		}, func(entry skippedPath) {
+			results <- scanResult{skipped: &entry}
+		})
+		close(jobs)
+		results <- scanResult{scanJob: scanJob{seq: seq}, walked: true, err: err}
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+type parseFailure struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+	Errors  int    `json:"errors"`
+	Partial bool   `json:"partial"`
+}
+
+func newParseFailure(rel string, err error, partial bool) *parseFailure {
+	failure := &parseFailure{File: rel, Message: err.Error(), Errors: 1, Partial: partial}
+	var list scanner.ErrorList
+	if errors.As(err, &list) && len(list) > 0 {
+		first := list[0]
+		failure.Line, failure.Column, failure.Message, failure.Errors = first.Pos.Line, first.Pos.Column, first.Msg, len(list)
+	}
+	return failure
+}
+
+func (p *parseFailure) String() string {
+	analysis := "file skipped"
+	if p.Partial {
+		analysis = "partial analysis"
+	}
+	return fmt.Sprintf("%s:%d:%d: %s (%d error(s), %s)", p.File, p.Line, p.Column, p.Message, p.Errors, analysis)
+}
+
+type summary struct {
+	FilesScanned  int            `json:"files_scanned"`
+	Findings      int            `json:"findings"`
+	BySeverity    map[string]int `json:"by_severity"`
+	ByRule        map[string]int `json:"by_rule"`
+	ParseFailures []parseFailure `json:"parse_failures"`
+	Skipped       []skippedPath  `json:"skipped"`
+	ExitCode      int            `json:"exit_code"`
+}
+
+// progress writes the stderr side of a scan: -verbose lines, or a bar that is
+// redrawn in place (at most every 100ms) and erased before any other output.
+// total stays 0 until the walk ends, and until then only a count is drawn.
+type progress struct {
+	quiet   bool
+	verbose bool
+	bar     bool
+	total   int
+	done    int
+	drawn   time.Time
+	shown   bool
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func (p *progress) clear() {
+	if p.bar && p.shown {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+		p.shown = false
+	}
+}
+
+// note prints a diagnostic line unless -quiet.
+func (p *progress) note(format string, args ...any) {
+	if p.quiet {
+		return
+	}
+	p.error(format, args...)
+}
+
+// error prints a line even under -quiet.
+func (p *progress) error(format string, args ...any) {
+	p.clear()
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func (p *progress) skip(entry skippedPath) {
+	if p.verbose {
+		p.note("skipped %s (%s)", entry.Path, entry.Reason)
+	}
+}
+
+func (p *progress) file(rel string, findings int, elapsed time.Duration) {
+	p.done++
+	if p.verbose {
+		p.note("scanned %s (%d finding(s), %s)", rel, findings, elapsed.Round(time.Microsecond))
+		return
+	}
+	if !p.bar || ((p.total == 0 || p.done < p.total) && time.Since(p.drawn) < 100*time.Millisecond) {
+		return
+	}
+	if runes := []rune(rel); len(runes) > 40 {
+		rel = "…" + string(runes[len(runes)-39:])
+	}
+	p.drawn, p.shown = time.Now(), true
+	if p.total == 0 {
+		// Still walking: the total is not known yet.
+		fmt.Fprintf(os.Stderr, "\r\033[K%d file(s) %s", p.done, rel)
+		return
+	}
+	const width = 24
+	filled := width * p.done / p.total
+	fmt.Fprintf(os.Stderr, "\r\033[K[%s%s] %d/%d %s",
+		strings.Repeat("#", filled), strings.Repeat(".", width-filled), p.done, p.total, rel)
+}
+
+func (p *progress) finish(report summary, elapsed time.Duration) {
+	p.clear()
+	if p.verbose {
+		p.note("done: %d file(s) scanned, %d path(s) skipped, %d finding(s) in %s",
+			report.FilesScanned, len(report.Skipped), report.Findings, elapsed.Round(time.Millisecond))
+	}
+}
+
+// parseLineRange accepts "START:END" or a single line number.
+func parseLineRange(value string) (int, int, error) {
+	startText, endText, found := strings.Cut(value, ":")
+	if !found {
+		endText = startText
+	}
+	start, err := strconv.Atoi(startText)
 	if err != nil {
-		return nil, err
+		return 0, 0, fmt.Errorf("invalid -range start %q", startText)
 	}
-	sort.Strings(files)
-	return files, nil
+	end, err := strconv.Atoi(endText)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid -range end %q", endText)
+	}
+	if start < 1 || end < start {
+		return 0, 0, fmt.Errorf("invalid -range %q", value)
+	}
+	return start, end, nil
 }
 
-func main() {
+func usageError(format string, args ...any) int {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	flag.Usage()
+	return exitUsage
+}
+
+func run() int {
+	summaryFormat := flag.String("summary", "", "print a machine-readable summary footer (json)")
+	failOn := flag.String("fail-on", "warning", "lowest severity that produces exit code 1 (info, warning, critical)")
+	lineRange := flag.String("range", "", "re-analyze only the functions overlapping START:END of a single .go file")
+	quiet := flag.Bool("quiet", false, "print findings and errors only (no progress, timing, or warnings on stderr)")
+	verbose := flag.Bool("verbose", false, "log per-file timing and skipped paths with reasons on stderr")
+	progressMode := flag.String("progress", "auto", "progress bar on stderr: auto (terminals only), always, never")
+	memoryLimit := flag.String("memory-limit", "0", "soft heap limit such as 512M or 2GiB, at least 16MiB; sizes the worker pool (0 = no limit)")
+	workers := flag.Int("workers", 0, "files analyzed in parallel (default GOMAXPROCS, lowered to fit -memory-limit)")
+	locale := flag.String("locale", "", "locale used to pick -messages catalogs (e.g. de, pt-BR; default en)")
+	var catalogs []string
+	flag.Func("messages", "JSON message catalog layered over the built-in messages (repeatable)", func(path string) error {
+		catalogs = append(catalogs, path)
+		return nil
+	})
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: resource_lifecycle_go.go [-summary json] [-fail-on severity] [-range START:END] [-quiet|-verbose] [-progress mode] [-memory-limit size] [-workers n] [-locale tag] [-messages catalog.json]... <project_dir|file.go>")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 	if flag.NArg() != 1 {
-		fmt.Fprintln(os.Stderr, "usage: resource_lifecycle_go.go <project_dir>")
-		os.Exit(2)
+		return usageError("expected exactly one project directory")
+	}
+	if *summaryFormat != "" && *summaryFormat != "json" {
+		return usageError("unsupported -summary format %q", *summaryFormat)
+	}
+	if *quiet && *verbose {
+		return usageError("-quiet and -verbose are mutually exclusive")
+	}
+	var showBar bool
+	switch *progressMode {
+	case "auto":
+		showBar = isTerminal(os.Stderr)
+	case "always":
+		showBar = true
+	case "never":
+	default:
+		return usageError("unsupported -progress mode %q", *progressMode)
+	}
+	threshold, ok := severityRank[*failOn]
+	if !ok {
+		return usageError("unsupported -fail-on severity %q", *failOn)
+	}
+	for _, path := range catalogs {
+		_, unknown, err := messages.load(path, *locale)
+		if err != nil {
+			return usageError("-messages %v", err)
+		}
+		if len(unknown) > 0 && !*quiet {
+			fmt.Fprintf(os.Stderr, "%s: ignoring unknown message id(s): %s\n", path, strings.Join(unknown, ", "))
+		}
 	}
 	root, err := filepath.Abs(flag.Arg(0))
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(2)
+		return usageError("%v", err)
+	}
+	info, err := os.Stat(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return usageError("%s does not exist", flag.Arg(0))
 	}
-	files, err := collectGoFiles(root)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(2)
+		return exitInternal
+	}
+	limit, err := parseByteSize(*memoryLimit)
+	if err != nil {
+		return usageError("%v", err)
+	}
+	if *workers < 0 {
+		return usageError("-workers must be positive")
+	}
+	if limit > 0 {
+		debug.SetMemoryLimit(limit)
+	}
+	started := time.Now()
+	analyze := analyzeFile
+	walk := func(visit func(string, int64), skip func(skippedPath)) error {
+		return walkGoFiles(root, visit, skip)
+	}
+	if !info.IsDir() {
+		// Findings are reported relative to the file's directory, so a single
+		// file prints as name.go:LINE rather than .:LINE.
+		file := root
+		root = filepath.Dir(root)
+		walk = func(visit func(string, int64), _ func(skippedPath)) error {
+			if strings.HasSuffix(file, ".go") {
+				visit(file, info.Size())
+			}
+			return nil
+		}
 	}
-	var outputs []string
-	for _, file := range files {
-		issues, err := analyzeFile(file, root)
+	if *lineRange != "" {
+		startLine, endLine, err := parseLineRange(*lineRange)
 		if err != nil {
+			return usageError("%v", err)
+		}
+		if info.IsDir() || !strings.HasSuffix(flag.Arg(0), ".go") {
+			return usageError("-range needs a single .go file")
+		}
+		analyze = func(path, _ string) ([]finding, *parseFailure) {
+			return ScanRange(path, startLine, endLine)
+		}
+	}
+	pool := poolSize(*workers, limit)
+	prog := &progress{quiet: *quiet, verbose: *verbose, bar: showBar && !*quiet && !*verbose}
+	if *verbose && limit > 0 {
+		prog.note("memory limit %s: %d worker(s)", *memoryLimit, pool)
+	}
+
+	report := summary{
+		BySeverity:    map[string]int{"critical": 0, "warning": 0, "info": 0},
+		ByRule:        map[string]int{},
+		ParseFailures: []parseFailure{},
+		Skipped:       []skippedPath{},
+	}
+	exitCode := exitClean
+	// Results arrive in completion order; pending holds the ones that finished
+	// ahead of the next file in walk order until it is printed.
+	window := make(chan struct{}, 4*pool)
+	pending := map[int]scanResult{}
+	next := 0
+	var walkErr error
+	results := scanFiles(walk, func(path string) ([]finding, *parseFailure) {
+		return analyze(path, root)
+	}, pool, newMemoryBudget(limit), window)
+	for result := range results {
+		switch {
+		case result.skipped != nil:
+			report.Skipped = append(report.Skipped, *result.skipped)
+			prog.skip(*result.skipped)
+			continue
+		case result.walked:
+			report.FilesScanned, walkErr = result.seq, result.err
+			prog.total = result.seq
 			continue
 		}
-		outputs = append(outputs, issues...)
+		pending[result.seq] = result
+		for {
+			done, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			<-window
+			if done.failure != nil {
+				// Surface the failure instead of treating the file as clean.
+				prog.error("parse error: %s", done.failure)
+				report.ParseFailures = append(report.ParseFailures, *done.failure)
+				if !done.failure.Partial {
+					entry := skippedPath{done.failure.File, "parse error"}
+					report.Skipped = append(report.Skipped, entry)
+					prog.skip(entry)
+				}
+			}
+			prog.file(relPath(root, done.path), len(done.issues), done.elapsed)
+			if len(done.issues) > 0 {
+				prog.clear()
+			}
+			for _, issue := range done.issues {
+				sev := severityOf(issue.kind)
+				report.BySeverity[sev]++
+				report.ByRule[string(issue.kind)]++
+				report.Findings++
+				if severityRank[sev] >= threshold {
+					exitCode = exitFindings
+				}
+				fmt.Println(issue.String())
+			}
+		}
+	}
+	if walkErr != nil {
+		prog.clear()
+		fmt.Fprintln(os.Stderr, walkErr)
+		return exitInternal
+	}
+	if len(report.ParseFailures) > 0 {
+		exitCode = exitInternal
 	}
-	if len(outputs) > 0 {
-		fmt.Println(strings.Join(outputs, "\n"))
+	report.ExitCode = exitCode
+	prog.finish(report, time.Since(started))
+
+	if *summaryFormat == "json" {
+		encoded, err := json.Marshal(report)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitInternal
+		}
+		fmt.Println(string(encoded))
 	}
+	return exitCode
+}
+
+func main() {
+	os.Exit(run())
 }
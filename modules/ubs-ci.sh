@@ -0,0 +1,851 @@
+#!/usr/bin/env bash
+# ═══════════════════════════════════════════════════════════════════════════
+# CI ULTIMATE BUG SCANNER v1.0.0 (Bash) - Pipeline Supply-Chain Analysis
+# ═══════════════════════════════════════════════════════════════════════════
+# Static analysis for CI configuration: GitHub Actions workflows
+# (.github/workflows/*.yml), composite actions (action.yml) and GitLab CI
+# (.gitlab-ci.yml, *.gitlab-ci.yml, .gitlab/**/*.yml) using an
+# indentation-aware python3 pass:
+#   • keys are tracked with their column so steps, jobs and `on:` triggers
+#     can be scoped without a YAML library
+#   • `run:` / `script:` bodies are read as shell, including block scalars
+#
+# Focus:
+#   • unpinned third-party actions    • pull_request_target + PR checkout
+#   • secrets echoed into job logs    • overly broad GITHUB_TOKEN scopes
+#
+# Supports:
+#   --format text|json|sarif (json/sarif => pure machine output)
+#   --fail-on-warning, --skip, --only, --jobs, --exclude
+#   --ci, --no-color, --summary-json
+# ═══════════════════════════════════════════════════════════════════════════
+
+if [ "${BASH_VERSINFO[0]:-0}" -lt 4 ]; then
+  echo "ERROR: ubs-ci.sh requires bash >= 4.0 (you have ${BASH_VERSION:-unknown})." >&2
+  echo "       On macOS: 'brew install bash' and re-run via /opt/homebrew/bin/bash." >&2
+  exit 2
+fi
+
+set -Eeuo pipefail
+umask 022
+shopt -s lastpipe
+shopt -s extglob
+
+SCRIPT_DIR="$(cd -- "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+
+# ────────────────────────────────────────────────────────────────────────────
+# Globals & defaults
+# ────────────────────────────────────────────────────────────────────────────
+
+VERBOSE=0
+PROJECT_DIR="."
+OUTPUT_FILE=""
+FORMAT="text"          # text|json|sarif
+CI_MODE=0
+FAIL_ON_WARNING=0
+QUIET=0
+NO_COLOR_FLAG=0
+EXTRA_EXCLUDES=""
+SKIP_CATEGORIES=""
+ONLY_CATEGORIES=""
+DETAIL_LIMIT=3
+MAX_DETAILED=250
+JOBS="${JOBS:-0}"
+
+# Comma-separated action owners (GitHub orgs/users) trusted like `actions/*`
+# and `github/*`: their actions may be referenced by tag instead of SHA.
+CI_TRUSTED_OWNERS="${UBS_CI_TRUSTED_OWNERS:-}"
+
+SUMMARY_JSON=""
+
+CHECK="✓"; CROSS="✗"; WARN="⚠"; INFO="ℹ"; ARROW="→"; BULLET="•"; FIRE="🔥"; SPARKLE="✨"; SHIELD="🛡"
+
+# Color handling
+USE_COLOR=1
+if [[ -n "${NO_COLOR:-}" || ! -t 1 ]]; then USE_COLOR=0; fi
+if [[ "$USE_COLOR" -eq 1 ]]; then
+  RED='\033[0;31m'; GREEN='\033[0;32m'; YELLOW='\033[1;33m'; BLUE='\033[0;34m'
+  MAGENTA='\033[0;35m'; CYAN='\033[0;36m'; WHITE='\033[1;37m'; GRAY='\033[0;90m'
+  BOLD='\033[1m'; DIM='\033[2m'; RESET='\033[0m'
+else
+  RED=''; GREEN=''; YELLOW=''; BLUE=''; MAGENTA=''; CYAN=''; WHITE=''; GRAY=''
+  BOLD=''; DIM=''; RESET=''
+fi
+
+# ────────────────────────────────────────────────────────────────────────────
+# Error handling
+# ────────────────────────────────────────────────────────────────────────────
+
+on_err() {
+  local ec=$?; local cmd=${BASH_COMMAND}; local line=${BASH_LINENO[0]}; local src=${BASH_SOURCE[1]:-${BASH_SOURCE[0]}}
+  if [[ "${FORMAT:-text}" == "json" || "${FORMAT:-text}" == "sarif" ]]; then
+    echo "{\"error\":{\"exit\":$ec,\"file\":\"$src\",\"line\":$line,\"cmd\":\"${cmd//\"/\\\"}\"}}" >&2; exit "$ec"
+  fi
+  echo -e "\n${RED}${BOLD}Unexpected error (exit $ec)${RESET} ${DIM}at ${src}:${line}${RESET}\n${DIM}Last command:${RESET} ${WHITE}$cmd${RESET}" >&2
+  exit "$ec"
+}
+trap on_err ERR
+
+print_usage() {
+  cat >&2 <<USAGE
+Usage: $(basename "$0") [options] [PROJECT_DIR] [OUTPUT_FILE]
+
+Options:
+  -v, --verbose            More code samples per finding (DETAIL=10)
+  --very-verbose           Max code samples (DETAIL=25)
+  -q, --quiet              Reduce non-essential output
+  --format=FMT             Output format: text|json|sarif (default: text)
+  --summary-json=FILE      Save brief summary counters JSON
+  --ci                     CI mode (no clear, stable timestamps)
+  --no-color               Force disable ANSI color
+  --exclude=GLOB[,..]      Additional glob(s)/dir(s) to exclude
+  --only=CSV               Only run these category numbers
+  --jobs=N                 Accepted for meta-runner compatibility
+  --skip=CSV               Skip categories by number (e.g. --skip=2,3)
+  --fail-on-warning        Exit non-zero on warnings or critical
+  --trusted-owners=CSV     Action owners that may be pinned by tag (besides actions, github)
+  -h, --help               Show help
+Env:
+  JOBS, NO_COLOR, CI, UBS_METRICS_DIR, UBS_CI_TRUSTED_OWNERS
+Args:
+  PROJECT_DIR              Directory to scan (default: ".")
+  OUTPUT_FILE              File to save the report (optional)
+USAGE
+}
+
+# CLI parsing
+while [[ $# -gt 0 ]]; do
+  case "$1" in
+    -v|--verbose) VERBOSE=1; DETAIL_LIMIT=10; shift;;
+    --very-verbose) VERBOSE=2; DETAIL_LIMIT=25; shift;;
+    -q|--quiet)   VERBOSE=0; DETAIL_LIMIT=1; QUIET=1; shift;;
+    --format=*)   FORMAT="${1#*=}"; shift;;
+    --summary-json=*) SUMMARY_JSON="${1#*=}"; shift;;
+    --ci)         CI_MODE=1; shift;;
+    --no-color)   NO_COLOR_FLAG=1; shift;;
+    --exclude=*)  EXTRA_EXCLUDES="${1#*=}"; shift;;
+    --only=*)     ONLY_CATEGORIES="${1#*=}"; shift;;
+    --jobs=*)     JOBS="${1#*=}"; shift;;
+    --skip=*)     SKIP_CATEGORIES="${1#*=}"; shift;;
+    --fail-on-warning) FAIL_ON_WARNING=1; shift;;
+    --trusted-owners=*) CI_TRUSTED_OWNERS="${1#*=}"; shift;;
+    -h|--help)    print_usage; exit 0;;
+    *)
+      if [[ -z "$PROJECT_DIR" || "$PROJECT_DIR" == "." ]] && ! [[ "$1" =~ ^- ]]; then
+        PROJECT_DIR="$1"; shift
+      elif [[ -z "$OUTPUT_FILE" ]] && ! [[ "$1" =~ ^- ]]; then
+        if [[ -e "$1" && -s "$1" ]]; then
+          echo "error: refusing to use existing non-empty file '$1' as OUTPUT_FILE (would be overwritten)." >&2
+          echo "       To scan multiple paths, use the meta-runner 'ubs'. To save a report, pass a fresh (non-existing) path." >&2
+          exit 2
+        fi
+        OUTPUT_FILE="$1"; shift
+      else
+        echo "Unexpected argument: $1" >&2; exit 2
+      fi
+      ;;
+  esac
+done
+
+# CI auto-detect + color override
+if [[ -n "${CI:-}" ]]; then CI_MODE=1; fi
+if [[ "$NO_COLOR_FLAG" -eq 1 ]]; then
+  USE_COLOR=0
+  RED=''; GREEN=''; YELLOW=''; BLUE=''; MAGENTA=''; CYAN=''; WHITE=''; GRAY=''
+  BOLD=''; DIM=''; RESET=''
+fi
+
+# Redirect output early to capture everything (honors machine formats too)
+if [[ -n "${OUTPUT_FILE}" ]]; then
+  if command -v tee >/dev/null 2>&1; then
+    exec > >(tee "${OUTPUT_FILE}") 2>&1
+  else
+    exec > "${OUTPUT_FILE}" 2>&1
+  fi
+fi
+
+DATE_FMT='%Y-%m-%d %H:%M:%S'
+safe_date() {
+  if [[ "$CI_MODE" -eq 1 ]]; then
+    command date -u '+%Y-%m-%dT%H:%M:%SZ' 2>/dev/null || command date '+%Y-%m-%dT%H:%M:%SZ'
+  else
+    command date "+$DATE_FMT"
+  fi
+}
+is_machine_format(){ [[ "$FORMAT" == "json" || "$FORMAT" == "sarif" ]]; }
+
+# If machine format: silence all user-facing text immediately.
+if is_machine_format; then
+  QUIET=1
+  USE_COLOR=0
+fi
+
+# ────────────────────────────────────────────────────────────────────────────
+# Global Counters
+# ────────────────────────────────────────────────────────────────────────────
+CRITICAL_COUNT=0
+WARNING_COUNT=0
+INFO_COUNT=0
+TOTAL_FILES=0
+
+# ────────────────────────────────────────────────────────────────────────────
+# Utilities
+# ────────────────────────────────────────────────────────────────────────────
+maybe_clear() { if [[ -t 1 && "$CI_MODE" -eq 0 ]] && ! is_machine_format; then clear || true; fi; }
+say() { [[ "$QUIET" -eq 1 ]] && return 0; echo -e "$*"; }
+
+json_escape() {
+  local s="${1-}"
+  s=${s//\\/\\\\}
+  s=${s//\"/\\\"}
+  s=${s//$'\n'/\\n}
+  s=${s//$'\r'/\\r}
+  s=${s//$'\t'/\\t}
+  printf '%s' "$s"
+}
+
+emit_json_summary() {
+  local ts json
+  ts="$(safe_date)"
+  json="$(printf '{"project":"%s","files":%s,"critical":%s,"warning":%s,"info":%s,"timestamp":"%s","format":"json"}\n' \
+    "$(json_escape "$PROJECT_DIR")" "$TOTAL_FILES" "$CRITICAL_COUNT" "$WARNING_COUNT" "$INFO_COUNT" "$(json_escape "$ts")")"
+  printf '%s' "$json"
+  if [[ -n "$SUMMARY_JSON" ]]; then
+    mkdir -p "$(dirname "$SUMMARY_JSON")" 2>/dev/null || true
+    printf '%s' "$json" >"$SUMMARY_JSON"
+  fi
+}
+
+emit_sarif() {
+  printf '%s\n' '{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"ubs-ci"}},"results":[]}]}'
+}
+print_header() { say "\n${CYAN}${BOLD}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${RESET}"; say "${WHITE}${BOLD}$1${RESET}"; say "${CYAN}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${RESET}"; }
+print_category() { say "\n${MAGENTA}${BOLD}▓▓▓ $1${RESET}"; say "${DIM}$2${RESET}"; }
+print_subheader() { say "\n${YELLOW}${BOLD}$BULLET $1${RESET}"; }
+print_finding() {
+  local severity=$1
+  case $severity in
+    good) local title=$2; say "  ${GREEN}${CHECK} OK${RESET} ${DIM}$title${RESET}" ;;
+    *)
+      local raw_count=$2; local title=$3; local description="${4:-}"
+      local count; count=$(printf '%s\n' "$raw_count" | awk 'END{print $0+0}')
+      case $severity in
+        critical) CRITICAL_COUNT=$((CRITICAL_COUNT + count)); say "  ${RED}${BOLD}${FIRE} CRITICAL${RESET} ${WHITE}($count found)${RESET}"; say "    ${RED}${BOLD}$title${RESET}"; [ -n "$description" ] && say "    ${DIM}$description${RESET}" || true ;;
+        warning)  WARNING_COUNT=$((WARNING_COUNT + count)); say "  ${YELLOW}${WARN} Warning${RESET} ${WHITE}($count found)${RESET}"; say "    ${YELLOW}$title${RESET}"; [ -n "$description" ] && say "    ${DIM}$description${RESET}" || true ;;
+        info)     INFO_COUNT=$((INFO_COUNT + count));      say "  ${BLUE}${INFO} Info${RESET} ${WHITE}($count found)${RESET}"; say "    ${BLUE}$title${RESET}"; [ -n "$description" ] && say "    ${DIM}$description${RESET}" || true ;;
+      esac
+      ;;
+  esac
+}
+print_code_sample() { local file=$1; local line=$2; local code=$3; say "${GRAY}      $file:$line${RESET}"; say "${WHITE}      $code${RESET}"; }
+
+begin_scan_section(){ set +o pipefail; set +e; trap - ERR; }
+end_scan_section(){ trap on_err ERR; set -e; set -o pipefail; }
+
+mktemp_file(){ mktemp 2>/dev/null || mktemp -t ubs-ci.XXXXXX; }
+
+# Path helpers & robust file discovery
+abspath() { perl -MCwd=abs_path -e 'print abs_path(shift)' -- "$1" 2>/dev/null || python3 - "$1" <<'PY'
+import os,sys; print(os.path.abspath(sys.argv[1]))
+PY
+}
+
+LC_ALL=C
+EXCLUDE_DIRS=(.git .hg .svn .bzr node_modules vendor dist build target .venv venv .cache .idea .vscode .history tmp log)
+if [[ -n "$EXTRA_EXCLUDES" ]]; then IFS=',' read -r -a _X <<<"$EXTRA_EXCLUDES"; EXCLUDE_DIRS+=("${_X[@]}"); fi
+
+# CI config lives at fixed paths rather than behind one extension, so match
+# workflow, composite action and GitLab locations directly.
+build_find_cmd() {
+  local -a prune=( )
+  for d in "${EXCLUDE_DIRS[@]}"; do prune+=( -name "$d" -o ); done
+  [[ ${#prune[@]} -gt 0 ]] && unset 'prune[${#prune[@]}-1]'
+  FIND_CMD=(find "$PROJECT_DIR" \( -type d \( "${prune[@]}" \) -prune \) -o \( -type f \( \
+    -path '*/.github/workflows/*.yml' -o -path '*/.github/workflows/*.yaml' -o \
+    -name 'action.yml' -o -name 'action.yaml' -o \
+    -name '.gitlab-ci.yml' -o -name '*.gitlab-ci.yml' -o -path '*/.gitlab/*.yml' \) -print0 \))
+}
+safe_count_files(){ tr -cd '\0' | awk 'END{print (length>0?gsub(/\0/,"")+0:0)}'; }
+
+# Category gating (run if returns 0)
+run_category() {
+  local cat="$1"
+  if [[ -n "$ONLY_CATEGORIES" ]]; then
+    IFS=',' read -r -a arr <<<"$ONLY_CATEGORIES"
+    for s in "${arr[@]}"; do [[ "$s" == "$cat" ]] && return 0; done
+    return 1
+  fi
+  if [[ -z "$SKIP_CATEGORIES" ]]; then return 0; fi
+  IFS=',' read -r -a arr <<<"$SKIP_CATEGORIES"
+  for s in "${arr[@]}"; do [[ "$s" == "$cat" ]] && return 1; done
+  return 0
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Workflow analyzer
+# ────────────────────────────────────────────────────────────────────────────
+# One python3 pass over every CI file; emits `rule<TAB>path<TAB>line<TAB>code`
+# rows into CI_FINDINGS_FILE. Each category then reports its own rule ids.
+
+CI_RULE_IDS=(
+  ci.actions.unpinned
+  ci.gitlab.unpinned-include
+  ci.actions.pull-request-target-checkout
+  ci.secrets.echoed
+  ci.actions.permissions-write-all
+  ci.actions.permissions-workflow-write
+  ci.actions.permissions-missing
+)
+
+declare -A CI_SUMMARY=(
+  [ci.actions.unpinned]="Third-party action referenced by a mutable tag or branch"
+  [ci.gitlab.unpinned-include]="GitLab include pulled from a mutable ref or remote URL"
+  [ci.actions.pull-request-target-checkout]="pull_request_target workflow checks out the PR head"
+  [ci.secrets.echoed]="Secret echoed into the job log"
+  [ci.actions.permissions-write-all]="permissions: write-all"
+  [ci.actions.permissions-workflow-write]="Write scope granted to every job in the workflow"
+  [ci.actions.permissions-missing]="Job runs with the repository's default token permissions"
+)
+
+declare -A CI_REMEDIATION=(
+  [ci.actions.unpinned]="Pin to the full 40-character commit SHA (keep the tag in a trailing comment); a moved or compromised tag otherwise runs new code with your secrets. Trusted owners: actions, github, plus UBS_CI_TRUSTED_OWNERS."
+  [ci.gitlab.unpinned-include]="Use project includes with ref: <commit SHA>, components at a released version, or vendor the template; remote URLs and branch refs change under you."
+  [ci.actions.pull-request-target-checkout]="pull_request_target runs with write tokens and secrets; building the contributor's head ref hands both to untrusted code. Use pull_request for builds, or split into a workflow_run follow-up that never executes PR code."
+  [ci.secrets.echoed]="Masking misses transformed values (base64, substrings, JSON); pipe secrets into the consuming command (--password-stdin) or write them to a file instead of printing them."
+  [ci.actions.permissions-write-all]="Grant only the scopes each job needs, e.g. permissions: { contents: read } at the top and per-job write scopes."
+  [ci.actions.permissions-workflow-write]="Set the workflow default to read and move write scopes onto the job that publishes, so lint/test jobs cannot push or release."
+  [ci.actions.permissions-missing]="Add a top-level permissions: block (contents: read) so the token does not inherit a read/write repository default."
+)
+
+declare -A CI_SEVERITY=(
+  [ci.actions.unpinned]="warning"
+  [ci.gitlab.unpinned-include]="warning"
+  [ci.actions.pull-request-target-checkout]="critical"
+  [ci.secrets.echoed]="critical"
+  [ci.actions.permissions-write-all]="critical"
+  [ci.actions.permissions-workflow-write]="warning"
+  [ci.actions.permissions-missing]="info"
+)
+
+CI_FINDINGS_FILE=""
+
+run_ci_analyzer() {
+  CI_FINDINGS_FILE="$(mktemp_file)"
+  if ! command -v python3 >/dev/null 2>&1; then
+    return 1
+  fi
+  python3 - "$PROJECT_DIR" "$CI_TRUSTED_OWNERS" "${EXCLUDE_DIRS[*]}" >"$CI_FINDINGS_FILE" <<'PY' || true
+import re
+import sys
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+TRUSTED_OWNERS = {'actions', 'github'} | {o.strip().lower() for o in sys.argv[2].split(',') if o.strip()}
+SKIP_DIRS = set(sys.argv[3].split())
+
+KEY_RE = re.compile(r'^(\s*)((?:-\s+)*)(["\']?)([A-Za-z0-9_.$-]+)\3\s*:(?:\s+(.*)|\s*)$')
+ITEM_RE = re.compile(r'^(\s*)-\s*(.*)$')
+BLOCK_SCALAR_RE = re.compile(r'^[|>][-+0-9]*$')
+FULL_SHA_RE = re.compile(r'^(?:[0-9a-f]{40}|[0-9a-f]{64})$')
+SEMVER_RE = re.compile(r'^v?\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)?$')
+HEAD_REF_RE = re.compile(r'github\.event\.pull_request\.head\.|github\.head_ref|refs/pull/|pull_request\.merge_commit_sha|github\.event\.number')
+PR_FETCH_RE = re.compile(r'\b(?:git\s+(?:checkout|fetch|pull|switch)|gh\s+pr\s+checkout)\b')
+SECRET_EXPR_RE = re.compile(r'\$\{\{[^}]*\bsecrets\.')
+SECRET_NAME_RE = re.compile(r'(?i)(?:TOKEN|SECRET|PASSW(?:OR)?D|API_?KEY|PRIVATE_?KEY|ACCESS_?KEY|CREDENTIALS?)')
+ECHO_RE = re.compile(r'^\s*(?:echo|printf|Write-Host|Write-Output|print)\b')
+VAR_REF_RE = re.compile(r'\$\{?([A-Za-z_][A-Za-z0-9_]*)\}?|\$env:([A-Za-z_][A-Za-z0-9_]*)|\benv\.([A-Za-z_][A-Za-z0-9_]*)')
+PIPE_RE = re.compile(r'(?<!\|)\|(?!\|)')
+REDIRECT_RE = re.compile(r'(?<![0-9&])>>?\s*(?!&)\S')
+SCRIPT_KEYS = {'run', 'script', 'before_script', 'after_script'}
+
+
+def should_skip(path: Path) -> bool:
+    try:
+        parts = path.relative_to(BASE_DIR).parts
+    except ValueError:
+        parts = path.parts
+    return any(part in SKIP_DIRS for part in parts[:-1])
+
+
+def ci_kind(path: Path):
+    name = path.name.lower()
+    if not name.endswith(('.yml', '.yaml')):
+        return None
+    parts = [p.lower() for p in path.parts]
+    if name in ('action.yml', 'action.yaml'):
+        return 'action'
+    for i in range(len(parts) - 2):
+        if parts[i] == '.github' and parts[i + 1] == 'workflows':
+            return 'workflow'
+    if name == '.gitlab-ci.yml' or name.endswith('.gitlab-ci.yml') or '.gitlab' in parts[:-1]:
+        return 'gitlab'
+    return None
+
+
+def iter_files(root: Path):
+    if root.is_file():
+        if ci_kind(root):
+            yield root
+        return
+    for path in sorted(root.rglob('*')):
+        if path.is_file() and ci_kind(path) and not should_skip(path):
+            yield path
+
+
+def relpath(path):
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+
+def strip_comment(line):
+    quote = ''
+    for i, ch in enumerate(line):
+        if quote:
+            if ch == quote:
+                quote = ''
+        elif ch in ('"', "'") and (i == 0 or line[i - 1] in ' \t:[{,-'):
+            quote = ch
+        elif ch == '#' and (i == 0 or line[i - 1] in ' \t'):
+            return line[:i].rstrip()
+    return line.rstrip()
+
+
+def unquote(value):
+    value = (value or '').strip()
+    if len(value) >= 2 and value[0] == value[-1] and value[0] in ('"', "'"):
+        return value[1:-1]
+    return value
+
+
+def indent_of(line):
+    return len(line) - len(line.lstrip(' '))
+
+
+class Doc:
+    """Line-oriented YAML view: keys with their column, plus shell script lines."""
+
+    def __init__(self, raw):
+        self.raw = raw.splitlines()
+        self.keys = []      # (idx, col, key, value)
+        self.scripts = []   # (idx, text)
+        self.items = []     # (idx, dash_col, value) for bare list items
+        block_col = None
+        block_script = False
+        list_col = None
+        for idx, raw_line in enumerate(self.raw):
+            if block_col is not None:
+                if not raw_line.strip() or indent_of(raw_line) > block_col:
+                    if block_script and raw_line.strip() and not raw_line.lstrip().startswith('#'):
+                        self.scripts.append((idx, raw_line.strip()))
+                    continue
+                block_col = None
+            line = strip_comment(raw_line)
+            if not line.strip():
+                continue
+            item = ITEM_RE.match(line)
+            if list_col is not None and item and len(item.group(1)) >= list_col:
+                value = item.group(2).strip()
+                self.items.append((idx, len(item.group(1)), value))
+                if BLOCK_SCALAR_RE.match(value):
+                    block_col, block_script = len(item.group(1)), True
+                elif value:
+                    self.scripts.append((idx, unquote(value)))
+                continue
+            list_col = None
+            m = KEY_RE.match(line)
+            if m:
+                col = len(m.group(1)) + len(m.group(2))
+                key, value = m.group(4), (m.group(5) or '').strip()
+                self.keys.append((idx, col, key, value))
+                is_script = key in SCRIPT_KEYS
+                if BLOCK_SCALAR_RE.match(value):
+                    block_col, block_script = col, is_script
+                elif is_script and value:
+                    self.scripts.append((idx, unquote(value)))
+                elif is_script:
+                    list_col = col
+                continue
+            if item:
+                self.items.append((idx, len(item.group(1)), item.group(2).strip()))
+
+    def top_level(self, name):
+        for idx, col, key, value in self.keys:
+            if col == 0 and key == name:
+                return idx, value
+        return None
+
+    def span(self, idx, col):
+        """Line indices nested under the node that starts at `idx` with column `col`."""
+        end = idx + 1
+        while end < len(self.raw):
+            line = self.raw[end]
+            if line.strip() and not line.lstrip().startswith('#') and indent_of(line) <= col:
+                break
+            end += 1
+        return range(idx + 1, end)
+
+    def keys_in(self, rng):
+        return [k for k in self.keys if k[0] in rng]
+
+    def step_of(self, idx, col):
+        """Span of the list item (step) that owns the key at `idx`/`col`."""
+        for j in range(idx, -1, -1):
+            line = strip_comment(self.raw[j])
+            m = ITEM_RE.match(line)
+            if m and len(m.group(1)) < col:
+                dash = len(m.group(1))
+                return range(j, self.span(j, dash).stop)
+        return range(idx, idx + 1)
+
+
+rows = []
+
+
+def emit(rule, path, doc, idx):
+    code = doc.raw[idx].strip().replace('\t', ' ')
+    if len(code) > 160:
+        code = code[:157] + '...'
+    rows.append((rule, relpath(path), idx + 1, code))
+
+
+def ignored(doc, idx):
+    if 'ubs:ignore' in doc.raw[idx]:
+        return True
+    return idx > 0 and 'ubs:ignore' in doc.raw[idx - 1]
+
+
+def unpinned_action(ref):
+    ref = unquote(ref)
+    if not ref or ref.startswith(('./', '.\\')) or '${{' in ref:
+        return False
+    if ref.startswith('docker://'):
+        return '@sha256:' not in ref
+    target, _, version = ref.partition('@')
+    owner = target.split('/', 1)[0].lower()
+    if owner in TRUSTED_OWNERS:
+        return False
+    return not FULL_SHA_RE.match(version.lower())
+
+
+def pr_target_trigger(doc):
+    on = doc.top_level('on') or doc.top_level('true')
+    if not on:
+        return False
+    idx, value = on
+    if 'pull_request_target' in value:
+        return True
+    return any('pull_request_target' in strip_comment(doc.raw[j]) for j in doc.span(idx, 0))
+
+
+def check_workflow(path, doc, kind):
+    pr_target = kind == 'workflow' and pr_target_trigger(doc)
+    for idx, col, key, value in doc.keys:
+        if ignored(doc, idx):
+            continue
+        if key == 'uses' and unpinned_action(value):
+            emit('ci.actions.unpinned', path, doc, idx)
+        if pr_target and key == 'uses' and unquote(value).lower().startswith('actions/checkout@'):
+            step = doc.step_of(idx, col)
+            if any(k in ('ref', 'repository') and HEAD_REF_RE.search(v) for _, _, k, v in doc.keys_in(step)):
+                emit('ci.actions.pull-request-target-checkout', path, doc, idx)
+    if pr_target:
+        for idx, text in doc.scripts:
+            if PR_FETCH_RE.search(text) and HEAD_REF_RE.search(text) and not ignored(doc, idx):
+                emit('ci.actions.pull-request-target-checkout', path, doc, idx)
+    if kind != 'workflow':
+        return
+
+    perms = doc.top_level('permissions')
+    if perms:
+        idx, value = perms
+        if value == 'write-all':
+            if not ignored(doc, idx):
+                emit('ci.actions.permissions-write-all', path, doc, idx)
+        elif value.startswith('{'):
+            if re.search(r':\s*write\b', value) and not ignored(doc, idx):
+                emit('ci.actions.permissions-workflow-write', path, doc, idx)
+        else:
+            for j, _, key, v in doc.keys_in(doc.span(idx, 0)):
+                if v == 'write' and not ignored(doc, j):
+                    emit('ci.actions.permissions-workflow-write', path, doc, j)
+    for idx, col, key, value in doc.keys:
+        if col > 0 and key == 'permissions' and value == 'write-all' and not ignored(doc, idx):
+            emit('ci.actions.permissions-write-all', path, doc, idx)
+
+    jobs = doc.top_level('jobs')
+    if perms or not jobs:
+        return
+    job_keys = doc.keys_in(doc.span(jobs[0], 0))
+    if not job_keys:
+        return
+    job_col = min(col for _, col, _, _ in job_keys)
+    for idx, col, key, _ in job_keys:
+        if col != job_col:
+            continue
+        inner = doc.keys_in(doc.span(idx, col))
+        child_col = min((c for _, c, _, _ in inner), default=None)
+        if not any(c == child_col and k == 'permissions' for _, c, k, _ in inner) and not ignored(doc, idx):
+            emit('ci.actions.permissions-missing', path, doc, idx)
+
+
+def check_gitlab(path, doc):
+    inc = doc.top_level('include')
+    if not inc:
+        return
+    idx, value = inc
+    if value:
+        if unquote(value).startswith(('http://', 'https://')) and not ignored(doc, idx):
+            emit('ci.gitlab.unpinned-include', path, doc, idx)
+        return
+    for j, col, key, v in doc.keys_in(doc.span(idx, 0)):
+        if ignored(doc, j):
+            continue
+        v = unquote(v)
+        if key == 'remote' and not re.search(r'/[0-9a-f]{40}/', v):
+            emit('ci.gitlab.unpinned-include', path, doc, j)
+        elif key == 'project':
+            item = doc.step_of(j, col)
+            refs = [unquote(rv) for _, _, rk, rv in doc.keys_in(item) if rk == 'ref']
+            if not refs or not FULL_SHA_RE.match(refs[0].lower()):
+                emit('ci.gitlab.unpinned-include', path, doc, j)
+        elif key == 'component':
+            _, _, version = v.partition('@')
+            if not (FULL_SHA_RE.match(version.lower()) or SEMVER_RE.match(version)):
+                emit('ci.gitlab.unpinned-include', path, doc, j)
+    for j, dash_col, v in doc.items:
+        v = unquote(v)
+        if j in doc.span(idx, 0) and v.startswith(('http://', 'https://')) and not ignored(doc, j):
+            emit('ci.gitlab.unpinned-include', path, doc, j)
+
+
+def secret_env_names(doc):
+    names = set()
+    for _, _, key, value in doc.keys:
+        if SECRET_EXPR_RE.search(value):
+            names.add(key)
+    return names
+
+
+def check_secret_echo(path, doc):
+    secret_names = secret_env_names(doc)
+    for idx, text in doc.scripts:
+        if ignored(doc, idx):
+            continue
+        for segment in re.split(r'&&|\|\||;', text):
+            if not ECHO_RE.match(segment) or '::add-mask::' in segment:
+                continue
+            if PIPE_RE.search(segment) or REDIRECT_RE.search(segment):
+                continue
+            leaked = bool(SECRET_EXPR_RE.search(segment))
+            for m in VAR_REF_RE.finditer(segment):
+                name = m.group(1) or m.group(2) or m.group(3)
+                if name in secret_names or SECRET_NAME_RE.search(name):
+                    leaked = True
+            if leaked:
+                emit('ci.secrets.echoed', path, doc, idx)
+                break
+
+
+for file_path in iter_files(ROOT):
+    try:
+        text = file_path.read_text(encoding='utf-8', errors='ignore')
+    except OSError:
+        continue
+    kind = ci_kind(file_path)
+    doc = Doc(text)
+    if kind == 'gitlab':
+        check_gitlab(file_path, doc)
+    else:
+        check_workflow(file_path, doc, kind)
+    check_secret_echo(file_path, doc)
+
+for rule, rel, line, code in rows:
+    print(f"{rule}\t{rel}\t{line}\t{code}")
+PY
+}
+
+# Report every rule id in "$@" from CI_FINDINGS_FILE; print the good message
+# when none of them fired.
+report_ci_rules() {
+  local good_msg="$1"; shift
+  local rule found=0
+  for rule in "$@"; do
+    local count
+    count=$(awk -F'\t' -v r="$rule" '$1==r' "$CI_FINDINGS_FILE" 2>/dev/null | awk 'END{print NR+0}')
+    [[ "$count" -gt 0 ]] || continue
+    found=1
+    print_finding "${CI_SEVERITY[$rule]}" "$count" "${CI_SUMMARY[$rule]}" "[$rule] ${CI_REMEDIATION[$rule]}"
+    local printed=0
+    while IFS=$'\t' read -r _rule file line code; do
+      [[ "$printed" -ge "$DETAIL_LIMIT" || "$printed" -ge "$MAX_DETAILED" ]] && break
+      print_code_sample "$file" "$line" "$code"
+      printed=$((printed + 1))
+    done < <(awk -F'\t' -v r="$rule" '$1==r' "$CI_FINDINGS_FILE" 2>/dev/null)
+  done
+  if [[ "$found" -eq 0 ]]; then
+    print_finding "good" "$good_msg"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Init
+# ────────────────────────────────────────────────────────────────────────────
+maybe_clear
+
+if ! is_machine_format; then
+echo -e "${BOLD}${CYAN}"
+cat <<'BANNER'
+╔══════════════════════════════════════════════════════════════════╗
+║   ██████╗██╗    ██╗   ██╗██████╗ ███████╗                        ║
+║  ██╔════╝██║    ██║   ██║██╔══██╗██╔════╝                        ║
+║  ██║     ██║    ██║   ██║██████╔╝███████╗                        ║
+║  ██║     ██║    ██║   ██║██╔══██╗╚════██║                        ║
+║  ╚██████╗██║    ╚██████╔╝██████╔╝███████║                        ║
+║   ╚═════╝╚═╝     ╚═════╝ ╚═════╝ ╚══════╝                        ║
+║                                                                  ║
+║  CI module • GitHub Actions & GitLab CI supply-chain hygiene     ║
+║  Run standalone: modules/ubs-ci.sh --help                        ║
+╚══════════════════════════════════════════════════════════════════╝
+BANNER
+echo -e "${RESET}"
+fi
+
+PROJECT_DIR="$(abspath "$PROJECT_DIR")"
+build_find_cmd
+say "${WHITE}Project:${RESET}  ${CYAN}$PROJECT_DIR${RESET}"
+say "${WHITE}Started:${RESET}  ${GRAY}$(safe_date)${RESET}"
+
+# Count files with robust find
+TOTAL_FILES=$( ( set +o pipefail; "${FIND_CMD[@]}" 2>/dev/null || true ) | safe_count_files )
+TOTAL_FILES=$(( TOTAL_FILES + 0 ))
+say "${WHITE}Files:${RESET}    ${CYAN}$TOTAL_FILES CI config files${RESET}"
+
+begin_scan_section
+
+HAS_ANALYZER=1
+run_ci_analyzer || HAS_ANALYZER=0
+trap '[[ -n "$CI_FINDINGS_FILE" ]] && rm -f "$CI_FINDINGS_FILE"' EXIT
+if [[ "$HAS_ANALYZER" -eq 0 ]]; then
+  say "${YELLOW}${WARN} python3 not found - CI workflow analysis disabled${RESET}"
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 1: SUPPLY-CHAIN PINNING
+# ═══════════════════════════════════════════════════════════════════════════
+if run_category 1; then
+print_header "1. SUPPLY-CHAIN PINNING"
+print_category "Detects: third-party actions on tags/branches, GitLab includes from mutable refs or remote URLs" \
+  "A tag can be moved after review; only a commit SHA pins the code that runs with your secrets."
+
+print_subheader "Unpinned actions and includes"
+if [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  report_ci_rules "Third-party actions and includes are pinned" ci.actions.unpinned ci.gitlab.unpinned-include
+else
+  print_finding "info" 0 "python3 not available" "Install python3 to enable action pinning checks"
+fi
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 2: UNTRUSTED TRIGGERS
+# ═══════════════════════════════════════════════════════════════════════════
+if run_category 2; then
+print_header "2. UNTRUSTED TRIGGERS"
+print_category "Detects: pull_request_target workflows that check out or fetch the PR head" \
+  "pull_request_target runs in the base repo's context; executing fork code there leaks secrets and write tokens."
+
+print_subheader "pull_request_target with PR checkout"
+if [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  report_ci_rules "No pull_request_target workflow runs PR code" ci.actions.pull-request-target-checkout
+else
+  print_finding "info" 0 "python3 not available" "Install python3 to enable trigger checks"
+fi
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 3: SECRET EXPOSURE
+# ═══════════════════════════════════════════════════════════════════════════
+if run_category 3; then
+print_header "3. SECRET EXPOSURE"
+print_category "Detects: echo/printf of secrets.* expressions, secret-backed env vars, or token-named variables" \
+  "Piped or redirected output and ::add-mask:: lines are exempt; anything printed to the log is not."
+
+print_subheader "Secrets printed in run/script steps"
+if [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  report_ci_rules "No secrets echoed into job logs" ci.secrets.echoed
+else
+  print_finding "info" 0 "python3 not available" "Install python3 to enable secret exposure checks"
+fi
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 4: TOKEN PERMISSIONS
+# ═══════════════════════════════════════════════════════════════════════════
+if run_category 4; then
+print_header "4. TOKEN PERMISSIONS"
+print_category "Detects: permissions: write-all, workflow-wide write scopes, jobs with no permissions at all" \
+  "The GITHUB_TOKEN should default to read and gain write scopes only on the job that needs them."
+
+print_subheader "GITHUB_TOKEN scopes"
+if [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  report_ci_rules "Token permissions are scoped per job" ci.actions.permissions-write-all ci.actions.permissions-workflow-write ci.actions.permissions-missing
+else
+  print_finding "info" 0 "python3 not available" "Install python3 to enable permission checks"
+fi
+fi
+
+end_scan_section
+
+# ═══════════════════════════════════════════════════════════════════════════
+# FINAL SUMMARY
+# ═══════════════════════════════════════════════════════════════════════════
+
+EXIT_CODE=0
+if [ "$CRITICAL_COUNT" -gt 0 ]; then EXIT_CODE=1; fi
+if [ "$FAIL_ON_WARNING" -eq 1 ] && [ $((CRITICAL_COUNT + WARNING_COUNT)) -gt 0 ]; then EXIT_CODE=1; fi
+
+if [[ "$FORMAT" == "json" ]]; then
+  emit_json_summary
+  exit "$EXIT_CODE"
+fi
+if [[ "$FORMAT" == "sarif" ]]; then
+  emit_sarif
+  exit "$EXIT_CODE"
+fi
+
+echo ""
+say "${BOLD}${WHITE}═══════════════════════════════════════════════════════════════════════════${RESET}"
+say "${BOLD}${CYAN}                    ${SHIELD} SCAN COMPLETE ${SHIELD}                                  ${RESET}"
+say "${BOLD}${WHITE}═══════════════════════════════════════════════════════════════════════════${RESET}"
+echo ""
+
+say "${WHITE}${BOLD}Summary Statistics:${RESET}"
+say "  ${WHITE}Files scanned:${RESET}    ${CYAN}$TOTAL_FILES${RESET}"
+say "  ${RED}${BOLD}Critical issues:${RESET}  ${RED}$CRITICAL_COUNT${RESET}"
+say "  ${YELLOW}Warning issues:${RESET}   ${YELLOW}$WARNING_COUNT${RESET}"
+say "  ${BLUE}Info items:${RESET}       ${BLUE}$INFO_COUNT${RESET}"
+echo ""
+
+if [ "$CRITICAL_COUNT" -eq 0 ] && [ "$WARNING_COUNT" -eq 0 ]; then
+  say "  ${GREEN}${BOLD}${SPARKLE} EXCELLENT! No critical or warning issues found ${SPARKLE}${RESET}"
+fi
+
+echo ""
+say "${DIM}Scan completed at: $(safe_date)${RESET}"
+
+if [[ -n "$OUTPUT_FILE" ]]; then
+  say "${GREEN}${CHECK} Full report saved to: ${CYAN}$OUTPUT_FILE${RESET}"
+fi
+if [[ -n "$SUMMARY_JSON" ]]; then
+  mkdir -p "$(dirname "$SUMMARY_JSON")" 2>/dev/null || true
+  printf '{"timestamp":"%s","files":%s,"critical":%s,"warning":%s,"info":%s}\n' \
+     "$(safe_date)" "$TOTAL_FILES" "$CRITICAL_COUNT" "$WARNING_COUNT" "$INFO_COUNT" >"$SUMMARY_JSON"
+fi
+
+echo ""
+say "${DIM}Add to pre-commit: ./ubs --ci --fail-on-warning --only=ci . > ci-bug-scan-report.txt${RESET}"
+echo ""
+
+exit "$EXIT_CODE"
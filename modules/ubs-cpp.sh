@@ -97,6 +97,30 @@ declare -A ASYNC_ERROR_SEVERITY=(
   [cpp.async.future-no-get]='warning'
 )
 
+# C memory/stream safety (unchecked returns, realloc leaks, cgo preambles)
+C_SAFETY_RULE_IDS=(cpp.c.unchecked-alloc cpp.c.unchecked-fopen cpp.c.realloc-self-assign cpp.c.ignored-return cpp.cgo.unsafe-string-api)
+declare -A C_SAFETY_SUMMARY=(
+  [cpp.c.unchecked-alloc]='malloc/calloc/realloc/strdup result used before a NULL check'
+  [cpp.c.unchecked-fopen]='fopen/fdopen/popen result used before a NULL check'
+  [cpp.c.realloc-self-assign]='p = realloc(p, ...) leaks the original block when realloc fails'
+  [cpp.c.ignored-return]='Return value of fread/fwrite/scanf/read/write/setuid-family call ignored'
+  [cpp.cgo.unsafe-string-api]='Unsafe C string API (strcpy/strcat/sprintf/gets) in a cgo preamble'
+)
+declare -A C_SAFETY_REMEDIATION=(
+  [cpp.c.unchecked-alloc]='Check the pointer against NULL before dereferencing or passing it to memcpy/memset/str* helpers'
+  [cpp.c.unchecked-fopen]='Check the FILE* against NULL (and report errno) before reading or writing through it'
+  [cpp.c.realloc-self-assign]='Assign to a temporary, check it, then replace the original pointer: tmp = realloc(p, n); if (!tmp) { free(p); ... }'
+  [cpp.c.ignored-return]='Check the count/status: short reads and writes, failed conversions, and failed privilege drops all report through the return value'
+  [cpp.cgo.unsafe-string-api]='Use snprintf/strlcpy with explicit sizes, or move the string handling to Go and pass lengths across the cgo boundary'
+)
+declare -A C_SAFETY_SEVERITY=(
+  [cpp.c.unchecked-alloc]='warning'
+  [cpp.c.unchecked-fopen]='warning'
+  [cpp.c.realloc-self-assign]='warning'
+  [cpp.c.ignored-return]='warning'
+  [cpp.cgo.unsafe-string-api]='critical'
+)
+
 print_usage() {
   cat >&2 <<USAGE
 Usage: $(basename "$0") [options] [PROJECT_DIR] [OUTPUT_FILE]
@@ -230,11 +254,12 @@ ASTG_VERSION=""
 AST_JSON_FILE=""
 
 # Resource lifecycle correlation spec (acquire vs release pairs)
-RESOURCE_LIFECYCLE_IDS=(thread_join malloc_heap fopen_handle)
+RESOURCE_LIFECYCLE_IDS=(thread_join malloc_heap fopen_handle cgo_alloc)
 declare -A RESOURCE_LIFECYCLE_SEVERITY=(
   [thread_join]="warning"
   [malloc_heap]="warning"
   [fopen_handle]="warning"
+  [cgo_alloc]="warning"
 )
 declare -A RESOURCE_LIFECYCLE_ACQUIRE=(
   [thread_join]='std::thread'
@@ -250,11 +275,13 @@ declare -A RESOURCE_LIFECYCLE_SUMMARY=(
   [thread_join]='std::thread started without join/detach'
   [malloc_heap]='malloc/calloc/realloc without free'
   [fopen_handle]='fopen without fclose'
+  [cgo_alloc]='cgo C.CString/C.CBytes/C.malloc without C.free'
 )
 declare -A RESOURCE_LIFECYCLE_REMEDIATION=(
   [thread_join]='Join or detach std::thread instances to avoid std::terminate'
   [malloc_heap]='Balance heap allocations with free() or prefer smart pointers'
   [fopen_handle]='Track FILE* handles and call fclose() or wrap in RAII'
+  [cgo_alloc]='C allocations are invisible to the Go GC; defer C.free(unsafe.Pointer(p)) right after the allocation'
 )
 
 # ────────────────────────────────────────────────────────────────────────────
@@ -608,6 +635,207 @@ run_async_error_checks() {
   fi
 }
 
+run_c_memory_safety_checks() {
+  print_subheader "C allocation/stream return checks (C, C++, cgo preambles)"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable C return-value checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity=${C_SAFETY_SEVERITY[$rule_id]:-warning}
+    local summary=${C_SAFETY_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${C_SAFETY_REMEDIATION[$rule_id]:-"Check C return values"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY'
+import re
+import sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', '.hg', '.svn', 'vendor', 'third_party', 'node_modules', '.cache', 'build', 'cmake-build-debug', 'cmake-build-release', 'dist', 'out', '_deps'}
+EXTS = {'.c', '.cc', '.cpp', '.cxx', '.c++', '.h', '.hh', '.hpp', '.hxx', '.ipp', '.tpp', '.ixx', '.cppm', '.mpp'}
+WINDOW = 40
+
+IDENT = r'[A-Za-z_][A-Za-z0-9_]*'
+ALLOC_RE = re.compile(rf'(?:^|[^\w.>])({IDENT})\s*=\s*(?:\([^()]*\)\s*)?(?:malloc|calloc|realloc|strdup|strndup|aligned_alloc)\s*\(')
+FOPEN_RE = re.compile(rf'(?:^|[^\w.>])({IDENT})\s*=\s*(?:fopen|fdopen|popen|tmpfile|freopen)\s*\(')
+REALLOC_SELF_RE = re.compile(rf'(?:^|[^\w.>])({IDENT})\s*=\s*(?:\([^()]*\)\s*)?realloc\s*\(\s*(\1)\s*,')
+IGNORED_CALL_RE = re.compile(
+    r'^\s*(?:fread|fwrite|fscanf|sscanf|scanf|read|write|pread|pwrite|setuid|setgid|seteuid|setegid|setresuid|setresgid|chdir|chroot)\s*\('
+)
+UNSAFE_API_RE = re.compile(r'(?<![\w.])(?:strcpy|strcat|sprintf|vsprintf|gets)\s*\(')
+CGO_IMPORT_RE = re.compile(r'^import\s+"C"\s*(?://.*)?$')
+
+def should_skip(path: Path) -> bool:
+    try:
+        parts = path.relative_to(BASE_DIR).parts
+    except ValueError:
+        parts = path.parts
+    return any(part in SKIP_DIRS for part in parts[:-1])
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() in EXTS or root.suffix == '.go':
+            yield root
+        return
+    for path in root.rglob('*'):
+        if path.is_file() and (path.suffix.lower() in EXTS or path.suffix == '.go') and not should_skip(path):
+            yield path
+
+def cgo_preamble(lines):
+    """C lines of the comment directly above `import "C"`; other lines blanked."""
+    import_idx = next((i for i, line in enumerate(lines) if CGO_IMPORT_RE.match(line)), None)
+    if import_idx is None:
+        return None
+    out = [''] * len(lines)
+    idx = import_idx - 1
+    if idx >= 0 and lines[idx].rstrip().endswith('*/'):
+        end = idx
+        while idx >= 0 and '/*' not in lines[idx]:
+            idx -= 1
+        if idx < 0:
+            return None
+        for n in range(idx, end + 1):
+            line = lines[n]
+            if n == idx:
+                line = line[line.index('/*') + 2:]
+            if n == end:
+                line = line[:line.rindex('*/')]
+            out[n] = line
+    else:
+        while idx >= 0 and lines[idx].lstrip().startswith('//'):
+            out[idx] = lines[idx][lines[idx].index('//') + 2:]
+            idx -= 1
+    return out
+
+def strip_code(lines):
+    """Blank out comments and string/char literals, preserving line structure."""
+    out, in_block = [], False
+    for line in lines:
+        buf, i, quote = [], 0, ''
+        while i < len(line):
+            ch, nxt = line[i], line[i + 1:i + 2]
+            if in_block:
+                if ch == '*' and nxt == '/':
+                    in_block = False
+                    i += 2
+                else:
+                    i += 1
+                continue
+            if quote:
+                if ch == '\\':
+                    i += 2
+                    continue
+                if ch == quote:
+                    quote = ''
+                    buf.append(ch)
+                i += 1
+                continue
+            if ch == '/' and nxt == '/':
+                break
+            if ch == '/' and nxt == '*':
+                in_block = True
+                i += 2
+                continue
+            if ch in ('"', "'"):
+                quote = ch
+            buf.append(ch)
+            i += 1
+        out.append(''.join(buf))
+    return out
+
+def null_check_re(name):
+    n = re.escape(name)
+    return re.compile(
+        rf'!\s*\(?\s*{n}\b(?!\s*[-.\[(])|\b{n}\s*[!=]=\s*(?:NULL|nullptr|0)\b|\b(?:NULL|nullptr)\s*[!=]=\s*{n}\b'
+        rf'|\bif\s*\(\s*{n}\s*(?:\)|&&|\|\|)|\bassert\s*\(\s*{n}\b|\breturn\s+{n}\s*;'
+    )
+
+def use_re(name, stream=False):
+    n = re.escape(name)
+    if stream:
+        return re.compile(
+            rf'\b(?:fread|fwrite|fprintf|fputs|fputc|fgets|fgetc|fscanf|fseek|ftell|fflush|getc|putc|rewind|vfprintf)\s*\([^;]*\b{n}\b'
+        )
+    return re.compile(
+        rf'\*\s*{n}\b|\b{n}\s*(?:\[|->)'
+        rf'|\b(?:memcpy|memmove|memset|strcpy|strncpy|strcat|strncat|sprintf|snprintf|strlen)\s*\(\s*{n}\b'
+    )
+
+def first_index(regex, lines, start, stop, first_col=0):
+    for idx in range(start, stop):
+        text = lines[idx][first_col:] if idx == start else lines[idx]
+        if regex.search(text):
+            return idx
+    return None
+
+def scope_end(lines, start):
+    for idx in range(start + 1, min(len(lines), start + WINDOW)):
+        if lines[idx].startswith('}'):
+            return idx
+    return min(len(lines), start + WINDOW)
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+issues = defaultdict(list)
+for path in sorted(iter_files(ROOT)):
+    try:
+        raw = path.read_text(encoding='utf-8', errors='ignore').splitlines()
+    except OSError:
+        continue
+    is_cgo = path.suffix == '.go'
+    if is_cgo:
+        source = cgo_preamble(raw)
+        if source is None:
+            continue
+    else:
+        source = raw
+    lines = strip_code(source)
+    for idx, text in enumerate(lines):
+        if 'ubs:ignore' in source[idx] or (idx > 0 and 'ubs:ignore' in source[idx - 1]):
+            continue
+        loc = (relpath(path), idx + 1)
+        m = REALLOC_SELF_RE.search(text)
+        if m:
+            issues['cpp.c.realloc-self-assign'].append(loc)
+        for regex, rule, stream in ((ALLOC_RE, 'cpp.c.unchecked-alloc', False), (FOPEN_RE, 'cpp.c.unchecked-fopen', True)):
+            m = regex.search(text)
+            if not m:
+                continue
+            name = m.group(1)
+            stop = scope_end(lines, idx)
+            check = first_index(null_check_re(name), lines, idx, stop)
+            scan = [re.sub(r'\bsizeof\s*\([^()]*\)', 'sizeof()', line) for line in lines]
+            use = first_index(use_re(name, stream), scan, idx, stop, m.end())
+            if use is not None and (check is None or use < check):
+                issues[rule].append(loc)
+        if IGNORED_CALL_RE.search(text) and not re.match(r'^\s*\(void\)', text):
+            issues['cpp.c.ignored-return'].append(loc)
+        if is_cgo and UNSAFE_API_RE.search(text):
+            issues['cpp.cgo.unsafe-string-api'].append(loc)
+
+for rule_id, hits in issues.items():
+    samples = ','.join(f'{name}:{line}' for name, line in hits[:3])
+    print(f"{rule_id}\t{len(hits)}\t{samples}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "Allocation/stream results are NULL-checked and I/O return values are consumed"
+  fi
+}
+
 run_archive_extraction_checks() {
   print_subheader "Archive extraction path traversal"
   if ! command -v python3 >/dev/null 2>&1; then
@@ -2957,7 +3185,19 @@ TOTAL_FILES=$(
   ( set +o pipefail; find "${SCAN_PATHS[@]}" "${EX_PRUNE[@]}" -o \( -type f "${NAME_EXPR[@]}" -print \) 2>/dev/null || true ) \
   | wc -l | awk '{print $1+0}'
 )
-say "${WHITE}Files:${RESET}    ${CYAN}$TOTAL_FILES source files (${INCLUDE_EXT})${RESET}"
+# Plain C sources and cgo shims are analyzed by the C return-value and
+# lifecycle helpers even though the C++ regex checks stay on INCLUDE_EXT.
+C_SOURCE_FILES=$(
+  ( set +o pipefail; find "${SCAN_PATHS[@]}" "${EX_PRUNE[@]}" -o \( -type f -name '*.c' -print \) 2>/dev/null || true ) \
+  | wc -l | awk '{print $1+0}'
+)
+CGO_FILES=$(
+  ( set +o pipefail; find "${SCAN_PATHS[@]}" "${EX_PRUNE[@]}" -o \( -type f -name '*.go' -print0 \) 2>/dev/null \
+    | xargs -0 grep -lE '^import[[:space:]]+"C"' 2>/dev/null || true ) \
+  | wc -l | awk '{print $1+0}'
+)
+TOTAL_FILES=$((TOTAL_FILES + C_SOURCE_FILES + CGO_FILES))
+say "${WHITE}Files:${RESET}    ${CYAN}$TOTAL_FILES source files (${INCLUDE_EXT},c + ${CGO_FILES} cgo)${RESET}"
 
 # ast-grep availability
 say ""
@@ -3152,7 +3392,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if should_skip 7; then
 print_header "7. UNDEFINED BEHAVIOR RISK ZONE"
-print_category "Detects: dangerous casts, unsafe C APIs, security-sensitive non-crypto randomness, request path traversal, open redirects, response header injection, outbound URL SSRF, archive traversal, delete mismatch" \
+print_category "Detects: dangerous casts, unsafe C APIs, unchecked malloc/fopen/read returns (incl. cgo preambles), security-sensitive non-crypto randomness, request path traversal, open redirects, response header injection, outbound URL SSRF, archive traversal, delete mismatch" \
   "UB can pass tests and still crash in production"
 
 print_subheader "Dangerous functions (strcpy/gets/scanf/sprintf)"
@@ -3162,6 +3402,8 @@ if [ "$count" -gt 0 ]; then
   show_detailed_finding "\\b(gets|strcpy|strcat|sprintf|scanf)\\s*\\(" 5
 fi
 
+run_c_memory_safety_checks
+
 print_subheader "Shell command execution APIs"
 shell_exec_pattern="\\b(std::)?system\\s*\\(|\\bpopen\\s*\\(|\\bShellExecute(A|W)?\\s*\\("
 count=$(search_count "$shell_exec_pattern")
@@ -3396,7 +3638,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if should_skip 16; then
 print_header "16. RESOURCE LIFECYCLE CORRELATION"
-print_category "Detects: std::thread spawn w/o join, malloc/calloc without free, fopen without fclose" \
+print_category "Detects: std::thread spawn w/o join, malloc/calloc without free, fopen without fclose, cgo C.CString/C.malloc without C.free" \
   "Manual resources must be paired with cleanup to avoid leaks and crashes"
 
 run_resource_lifecycle_checks
@@ -27,6 +27,7 @@
 #       - unbounded JSON decode/read from req.Body aliases (MaxBytesReader heuristic)
 #       - dynamic SQL string at Exec/Query sinks, strings.Fields in exec
 #   • New categories 20–22 for crashers, DB robustness, and shutdown hygiene
+#   • Category 23 for floating-point equality and money arithmetic
 # ═══════════════════════════════════════════════════════════════════════════
 
 if [ "${BASH_VERSINFO[0]:-0}" -lt 4 ]; then
@@ -128,8 +129,206 @@ declare -A TAINT_SEVERITY=(
   [go.taint.command]='critical'
 )
 
+# Deserialization / decoder safety metadata
+DECODER_SAFETY_RULE_IDS=(go.sec.json-untrusted-any go.sec.gob-untrusted-any go.sec.xml-untrusted go.sec.yaml-untrusted)
+declare -A DECODER_SAFETY_SUMMARY=(
+  [go.sec.json-untrusted-any]='Untrusted JSON decoded into interface{} without a size limit'
+  [go.sec.gob-untrusted-any]='Untrusted gob stream decoded into interface{} without a size limit'
+  [go.sec.xml-untrusted]='xml.Decoder on untrusted input without size limit or with relaxed entity handling'
+  [go.sec.yaml-untrusted]='yaml.Unmarshal/Decode of user input without size limit or into recursive types'
+)
+declare -A DECODER_SAFETY_REMEDIATION=(
+  [go.sec.json-untrusted-any]='Wrap the body with http.MaxBytesReader/io.LimitReader and decode into a concrete struct with Decoder.DisallowUnknownFields()'
+  [go.sec.gob-untrusted-any]='gob is not hardened for hostile input: bound the stream with io.LimitReader, decode into concrete registered types, or switch to a schema-checked format'
+  [go.sec.xml-untrusted]='Bound input with io.LimitReader/http.MaxBytesReader, keep Decoder.Strict=true, and do not install custom Entity maps or CharsetReader for untrusted XML'
+  [go.sec.yaml-untrusted]='Cap the payload with http.MaxBytesReader, prefer gopkg.in/yaml.v3 (alias-expansion limits), and decode into flat, non-recursive structs'
+)
+declare -A DECODER_SAFETY_SEVERITY=(
+  [go.sec.json-untrusted-any]='warning'
+  [go.sec.gob-untrusted-any]='warning'
+  [go.sec.xml-untrusted]='warning'
+  [go.sec.yaml-untrusted]='warning'
+)
+
+# Unbounded resource growth (memory-leak heuristics)
+GROWTH_RULE_IDS=(go.growth.unbounded-cache go.growth.goroutine-per-request)
+declare -A GROWTH_SUMMARY=(
+  [go.growth.unbounded-cache]='Package-level map/slice cache written from handlers with no eviction path'
+  [go.growth.goroutine-per-request]='Handler spawns a goroutine per request without a worker pool or semaphore'
+)
+declare -A GROWTH_REMEDIATION=(
+  [go.growth.unbounded-cache]='Bound the cache (LRU/TTL), delete entries when they expire, or periodically clear() it; unbounded package-level caches grow with traffic until OOM'
+  [go.growth.goroutine-per-request]='Hand work to a fixed worker pool, or gate launches with a semaphore (chan struct{}, semaphore.Weighted, errgroup.SetLimit)'
+)
+declare -A GROWTH_SEVERITY=(
+  [go.growth.unbounded-cache]='warning'
+  [go.growth.goroutine-per-request]='warning'
+)
+
+# Time and timezone correctness
+TIME_RULE_IDS=(go.time.wall-clock-elapsed go.time.parse-no-location go.time.sleep-in-test go.time.equality)
+declare -A TIME_SUMMARY=(
+  [go.time.wall-clock-elapsed]='Elapsed time computed from wall-clock Unix() arithmetic instead of the monotonic clock'
+  [go.time.parse-no-location]='time.Parse with a zone-less layout silently yields UTC'
+  [go.time.sleep-in-test]='time.Sleep used for synchronization in tests'
+  [go.time.equality]='time.Time compared with ==/!= instead of Equal'
+)
+declare -A TIME_REMEDIATION=(
+  [go.time.wall-clock-elapsed]='Keep the time.Time from time.Now() and use time.Since/t.Sub; Unix() values drop the monotonic reading and jump with NTP or manual clock changes'
+  [go.time.parse-no-location]='Use time.ParseInLocation with an explicit *time.Location, or a layout carrying a zone (time.RFC3339, Z07:00, MST)'
+  [go.time.sleep-in-test]='Wait on a channel, sync.WaitGroup, or a polling helper with a deadline; fixed sleeps make tests slow and flaky under load'
+  [go.time.equality]='Use t.Equal(u); == also compares the Location pointer and monotonic reading, so equal instants can compare unequal'
+)
+declare -A TIME_SEVERITY=(
+  [go.time.wall-clock-elapsed]='warning'
+  [go.time.parse-no-location]='warning'
+  [go.time.sleep-in-test]='warning'
+  [go.time.equality]='warning'
+)
+
+# Floating-point and money arithmetic
+NUMERIC_RULE_IDS=(go.float.equality go.money.float-accumulation go.money.float-to-int-truncation)
+declare -A NUMERIC_SUMMARY=(
+  [go.float.equality]='Floating-point values compared with ==/!='
+  [go.money.float-accumulation]='Monetary float accumulated inside a loop'
+  [go.money.float-to-int-truncation]='Float converted to an integer amount by truncation (lost cents)'
+)
+declare -A NUMERIC_REMEDIATION=(
+  [go.float.equality]='Compare with a tolerance (math.Abs(a-b) < eps) or keep the values as integers/decimals; 0.1+0.2 != 0.3 in binary floating point'
+  [go.money.float-accumulation]='Store money as integer minor units (int64 cents) or a decimal type (shopspring/decimal, apd); repeated float adds drift by fractions of a cent'
+  [go.money.float-to-int-truncation]='Round before converting (int64(math.Round(amount*100))) or avoid floats for money entirely; int64(19.99*100) == 1998'
+)
+declare -A NUMERIC_SEVERITY=(
+  [go.float.equality]='warning'
+  [go.money.float-accumulation]='warning'
+  [go.money.float-to-int-truncation]='warning'
+)
+
+# gRPC streaming call sites
+GRPC_STREAM_RULE_IDS=(go.grpc.stream-recv-error-ignored go.grpc.stream-send-error-ignored)
+declare -A GRPC_STREAM_SUMMARY=(
+  [go.grpc.stream-recv-error-ignored]='gRPC stream Recv error ignored or only compared with io.EOF'
+  [go.grpc.stream-send-error-ignored]='gRPC stream Send error ignored'
+)
+declare -A GRPC_STREAM_REMEDIATION=(
+  [go.grpc.stream-recv-error-ignored]='Check err != nil after every Recv/CloseAndRecv; on a broken stream Recv keeps returning (nil, err), so an unchecked loop spins or dereferences a nil message'
+  [go.grpc.stream-send-error-ignored]='Return or handle the error from Send/SendMsg/SendAndClose; once the peer is gone every Send fails and the handler keeps producing into a dead stream'
+)
+declare -A GRPC_STREAM_SEVERITY=(
+  [go.grpc.stream-recv-error-ignored]='critical'
+  [go.grpc.stream-send-error-ignored]='warning'
+)
+
+# Environment variables and configuration loading
+ENV_CONFIG_RULE_IDS=(go.env.strconv-error-ignored go.env.getenv-unchecked go.env.scattered-read)
+declare -A ENV_CONFIG_SUMMARY=(
+  [go.env.strconv-error-ignored]='Env var parsed with strconv/time and the error discarded (medium confidence)'
+  [go.env.getenv-unchecked]='os.Getenv value used without empty-value handling (medium confidence)'
+  [go.env.scattered-read]='Environment read inside business logic instead of a config struct (medium confidence)'
+)
+declare -A ENV_CONFIG_REMEDIATION=(
+  [go.env.strconv-error-ignored]='An unset or malformed variable silently becomes 0/false; check the error and fail at startup, or fall back to an explicit default'
+  [go.env.getenv-unchecked]='os.Getenv returns "" for unset variables; compare against "", use os.LookupEnv, or apply a default (cmp.Or) before the value reaches a DSN, address, or header'
+  [go.env.scattered-read]='Load the environment once at startup into a typed config struct, validate it there, and pass the struct (or fields) to handlers and services'
+)
+declare -A ENV_CONFIG_SEVERITY=(
+  [go.env.strconv-error-ignored]='warning'
+  [go.env.getenv-unchecked]='warning'
+  [go.env.scattered-read]='info'
+)
+
+# Typed nil stored in interfaces & interface compliance
+NIL_IFACE_RULE_IDS=(go.nil.typed-nil-return go.nil.typed-nil-interface-compare go.iface.missing-assertion)
+declare -A NIL_IFACE_SUMMARY=(
+  [go.nil.typed-nil-return]='Possibly-nil concrete pointer returned as error (typed nil)'
+  [go.nil.typed-nil-interface-compare]='Interface compared to nil after a concrete pointer was assigned to it'
+  [go.iface.missing-assertion]='Type documented to implement an interface has no compile-time assertion'
+)
+declare -A NIL_IFACE_REMEDIATION=(
+  [go.nil.typed-nil-return]='An error holding a nil *T is not == nil, so callers take the failure path; return a literal nil when there is no error, or declare the variable as error'
+  [go.nil.typed-nil-interface-compare]='The interface carries a type even when the pointer is nil, so the comparison is always true/false; check the concrete pointer before assigning, or keep the variable concrete'
+  [go.iface.missing-assertion]='Add var _ Iface = (*T)(nil) next to the type so a changed method set fails the build instead of a runtime type assertion'
+)
+declare -A NIL_IFACE_SEVERITY=(
+  [go.nil.typed-nil-return]='critical'
+  [go.nil.typed-nil-interface-compare]='critical'
+  [go.iface.missing-assertion]='info'
+)
+
+# context.WithValue keys, payloads, and accessors
+CONTEXT_VALUE_RULE_IDS=(go.context.basic-key go.context.value-without-accessor go.context.heavy-value)
+declare -A CONTEXT_VALUE_SUMMARY=(
+  [go.context.basic-key]='context.WithValue key is a string or other built-in type'
+  [go.context.value-without-accessor]='ctx.Value read outside a type-checked accessor helper'
+  [go.context.heavy-value]='Logger or large struct stored in a context value'
+)
+declare -A CONTEXT_VALUE_REMEDIATION=(
+  [go.context.basic-key]='Any package can read or overwrite a string/int key; declare an unexported key type (type ctxKey int or struct{}) so keys cannot collide'
+  [go.context.value-without-accessor]='Wrap each key in WithX/XFromContext helpers that use the comma-ok assertion; a bare .(T) panics when the value is missing or has another type'
+  [go.context.heavy-value]='Context values are request-scoped data; pass loggers and configuration explicitly or store a pointer, since every WithValue copies the value into a new node'
+)
+declare -A CONTEXT_VALUE_SEVERITY=(
+  [go.context.basic-key]='warning'
+  [go.context.value-without-accessor]='warning'
+  [go.context.heavy-value]='info'
+)
+
+# init() side effects and package-level state
+PACKAGE_STATE_RULE_IDS=(go.init.heavy-work go.global.unsynchronized-mutation go.flag.parse-outside-main)
+declare -A PACKAGE_STATE_SUMMARY=(
+  [go.init.heavy-work]='Network, file, or process work inside init()'
+  [go.global.unsynchronized-mutation]='Package-level map/slice mutated at runtime without a lock'
+  [go.flag.parse-outside-main]='flag.Parse() called from init() or library code'
+)
+declare -A PACKAGE_STATE_REMEDIATION=(
+  [go.init.heavy-work]='init() runs on import, before main and in every test binary; failures cannot be returned or retried. Move the work into an explicit constructor or Load function called from main'
+  [go.global.unsynchronized-mutation]='Concurrent handlers writing a shared map crash with "concurrent map writes"; guard it with a sync.Mutex, use sync.Map, or move the state into a struct owned by one goroutine'
+  [go.flag.parse-outside-main]='Parsing in init() or a library runs before main registers its own flags and breaks go test flags; only main (or TestMain) should call flag.Parse()'
+)
+declare -A PACKAGE_STATE_SEVERITY=(
+  [go.init.heavy-work]='warning'
+  [go.global.unsynchronized-mutation]='warning'
+  [go.flag.parse-outside-main]='warning'
+)
+
+# Generated code provenance (protoc-gen-go, mockgen, stringer)
+GENERATED_RULE_IDS=(go.gen.hand-edited go.gen.stale go.gen.checksum-drift)
+declare -A GENERATED_SUMMARY=(
+  [go.gen.hand-edited]='Generated Go file edited by hand (declarations the generator never writes, or a missing/misplaced DO NOT EDIT header)'
+  [go.gen.stale]='Generated Go file is stale relative to its source'
+  [go.gen.checksum-drift]='Generated Go file no longer matches its recorded checksum'
+)
+declare -A GENERATED_REMEDIATION=(
+  [go.gen.hand-edited]='The next go generate silently discards hand edits; move the code into a separate non-generated file in the same package and regenerate'
+  [go.gen.stale]='The checked-in output does not reflect the current .proto, interface, or const block; rerun go generate (or protoc) and commit the result'
+  [go.gen.checksum-drift]='Regenerate the file, or refresh .ubs-generated.sha256 with sha256sum if the change came from the generator'
+)
+declare -A GENERATED_SEVERITY=(
+  [go.gen.hand-edited]='warning'
+  [go.gen.stale]='warning'
+  [go.gen.checksum-drift]='warning'
+)
+
+# sync/atomic misuse: mixed access, lock copies, check-then-act
+ATOMIC_RULE_IDS=(go.atomic.mixed-access go.sync.lock-copy go.atomic.check-then-act)
+declare -A ATOMIC_SUMMARY=(
+  [go.atomic.mixed-access]='Variable accessed both through sync/atomic and with plain reads/writes'
+  [go.sync.lock-copy]='Struct holding a sync.Mutex/WaitGroup/atomic value copied by value'
+  [go.atomic.check-then-act]='atomic Load followed by Store on the same value (check-then-act race)'
+)
+declare -A ATOMIC_REMEDIATION=(
+  [go.atomic.mixed-access]='One plain access is enough for a data race and a torn read; route every read and write through atomic.Load/Store (or switch the field to atomic.Int64/atomic.Bool so plain access does not compile)'
+  [go.sync.lock-copy]='A copied Mutex or WaitGroup is a separate lock with the state it had at copy time; use pointer receivers and parameters, and range over indexes or pointers instead of values'
+  [go.atomic.check-then-act]='Another goroutine can change the value between the Load and the Store; use CompareAndSwap in a loop (or Add/Swap) so the update is a single atomic step'
+)
+declare -A ATOMIC_SEVERITY=(
+  [go.atomic.mixed-access]='critical'
+  [go.sync.lock-copy]='warning'
+  [go.atomic.check-then-act]='warning'
+)
+
 # Resource lifecycle correlation spec (acquire vs release pairs)
-RESOURCE_LIFECYCLE_IDS=(context_cancel ticker_stop timer_stop file_handle db_handle mutex_lock)
+RESOURCE_LIFECYCLE_IDS=(context_cancel ticker_stop timer_stop file_handle db_handle mutex_lock wrapper_close)
 declare -A RESOURCE_LIFECYCLE_SEVERITY=(
   [context_cancel]="critical"
   [ticker_stop]="warning"
@@ -137,6 +336,7 @@ declare -A RESOURCE_LIFECYCLE_SEVERITY=(
   [file_handle]="warning"
   [db_handle]="warning"
   [mutex_lock]="warning"
+  [wrapper_close]="warning"
 )
 declare -A RESOURCE_LIFECYCLE_ACQUIRE=(
   [context_cancel]='context\.With(Cancel|Timeout|Deadline)\('
@@ -145,6 +345,7 @@ declare -A RESOURCE_LIFECYCLE_ACQUIRE=(
   [file_handle]='os\.(Open|OpenFile)\('
   [db_handle]='sql\.Open(DB)?\('
   [mutex_lock]='\.Lock\('
+  [wrapper_close]='(gzip|zlib|flate|lzw)\.New(Reader|Writer)'
 )
 declare -A RESOURCE_LIFECYCLE_RELEASE=(
   [context_cancel]='cancel\('
@@ -153,6 +354,7 @@ declare -A RESOURCE_LIFECYCLE_RELEASE=(
   [file_handle]='\.Close\('
   [db_handle]='\.Close\('
   [mutex_lock]='\.Unlock\('
+  [wrapper_close]='\.Close\('
 )
 declare -A RESOURCE_LIFECYCLE_SUMMARY=(
   [context_cancel]='context.With* without deferred cancel'
@@ -161,6 +363,7 @@ declare -A RESOURCE_LIFECYCLE_SUMMARY=(
   [file_handle]='os.Open/OpenFile without defer Close()'
   [db_handle]='sql.Open without DB.Close()'
   [mutex_lock]='Mutex Lock without Unlock()'
+  [wrapper_close]='gzip/zlib/flate wrapper not closed'
 )
 declare -A RESOURCE_LIFECYCLE_REMEDIATION=(
   [context_cancel]='Store the cancel func and defer cancel() immediately after acquiring the context'
@@ -169,6 +372,7 @@ declare -A RESOURCE_LIFECYCLE_REMEDIATION=(
   [file_handle]='Call defer f.Close() immediately after Open to avoid FD leaks'
   [db_handle]='Close sql.DB handles when shutting down or prefer context-managed lifecycle'
   [mutex_lock]='Pair Lock() with defer Unlock() to avoid deadlocks when returning early'
+  [wrapper_close]='Close each layer: the wrapper Close flushes writers and frees decompressor state but never closes the file or body underneath'
 )
 
 print_usage() {
@@ -201,7 +405,7 @@ Options:
   -h, --help               Show help
 
 Env:
-  JOBS, NO_COLOR, CI, UBS_CATEGORY_FILTER
+  JOBS, NO_COLOR, CI, UBS_CATEGORY_FILTER, UBS_MEMORY_LIMIT (Go AST helper heap cap, e.g. 2G)
 Args:
   PROJECT_DIR              Directory to scan (default: ".")
   OUTPUT_FILE              File to save the report (optional)
@@ -544,6 +748,152 @@ rg_or_grep_targets() {
   done <<<"$files"
 }
 
+# Apply the per-path severity overrides from .ubscan.yaml (exported by ubs as
+# UBS_OVERRIDES) to rule rows. Default input is `rule<TAB>count<TAB>hits` where
+# hits lists every location; each hit is resolved against the overrides (later
+# entries win, a `rules:` match beats the entry's blanket `severity:`), hits
+# resolved to `off` are dropped, and the rest are regrouped per severity with
+# samples trimmed to three (UBS_SAMPLE_LIMIT overrides; 0 keeps every hit).
+# Output gains a fourth column holding the override severity: empty for the
+# rule default, `<=LEVEL` for a blanket cap, else the level a `rules:` entry
+# set; override_severity turns it into the printed severity. With
+# --per-finding PREFIX the input is the
+# resource helper's `location<TAB>kind<TAB>message` and the rule id is PREFIX+kind.
+apply_rule_overrides() {
+  python3 -c "$(cat <<'PY'
+import fnmatch, json, os, re, sys
+
+LEVELS = {'off', 'info', 'warning', 'critical'}
+SAMPLE_LIMIT = int(os.environ.get('UBS_SAMPLE_LIMIT') or 3) or None
+HIT_SPLIT = re.compile(r',\s*(?=[^,\s()]+:\d+)')
+HIT_PATH = re.compile(r'^(.+?):\d+')
+
+try:
+    OVERRIDES = json.loads(os.environ.get('UBS_OVERRIDES') or '[]')
+except ValueError:
+    OVERRIDES = []
+if not isinstance(OVERRIDES, list):
+    OVERRIDES = []
+
+def glob_regex(pattern):
+    """`*` and `?` stay inside one path segment, `**` spans segments, and
+    `**/` and a trailing `/**` also match zero directories. Override paths in
+    modules/ubs-golang.sh and policy `matches` in ubs share this dialect;
+    test-suite/meta/glob_dialect.sh checks the two copies are identical."""
+    out, i = [], 0
+    while i < len(pattern):
+        if pattern.startswith('**/', i):
+            out.append('(?:.*/)?')
+            i += 3
+        elif pattern.endswith('/**') and i == len(pattern) - 3:
+            out.append('(?:/.*)?')
+            i += 3
+        elif pattern.startswith('**', i):
+            out.append('.*')
+            i += 2
+        elif pattern[i] == '*':
+            out.append('[^/]*')
+            i += 1
+        elif pattern[i] == '?':
+            out.append('[^/]')
+            i += 1
+        elif pattern[i] == '[' and ']' in pattern[i + 2:]:
+            end = pattern.index(']', i + 2)
+            body = pattern[i + 1:end]
+            out.append('[' + ('^' + body[1:] if body.startswith('!') else body).replace('\\', '\\\\') + ']')
+            i = end + 1
+        else:
+            out.append(re.escape(pattern[i]))
+            i += 1
+    return ''.join(out)
+
+def path_matches(pattern, path):
+    # Patterns are anchored at the project root (`*.pb.go` is top-level only;
+    # `**/*.pb.go` is any depth), and matching a directory covers its subtree,
+    # so `dir`, `dir/*` and `dir/**` are equivalent.
+    pattern = str(pattern).strip()
+    while pattern.startswith('./'):
+        pattern = pattern[2:]
+    pattern = pattern.strip('/')
+    path = str(path)
+    while path.startswith('./'):
+        path = path[2:]
+    if pattern in ('', '**'):
+        return True
+    return re.fullmatch(glob_regex(pattern) + '(?:/.*)?', path) is not None
+
+def resolve(rule, path):
+    level = ''
+    for entry in OVERRIDES:
+        if not isinstance(entry, dict) or not path_matches(str(entry.get('path', '')), path):
+            continue
+        rules = entry.get('rules') or {}
+        picked = rules.get(rule)
+        if picked is None:
+            picked = next((v for k, v in rules.items() if fnmatch.fnmatchcase(rule, k)), None)
+        if picked is None:
+            # A blanket `severity:` caps the rule default (see override_severity);
+            # only `off` is absolute.
+            picked = entry.get('severity')
+            if picked in LEVELS:
+                level = picked if picked == 'off' else '<=' + picked
+        elif picked in LEVELS:
+            level = picked
+    return level
+
+def hit_path(hit):
+    m = HIT_PATH.match(hit.strip())
+    return m.group(1) if m else ''
+
+if len(sys.argv) > 2 and sys.argv[1] == '--per-finding':
+    prefix = sys.argv[2]
+    for raw in sys.stdin:
+        line = raw.rstrip('\n')
+        parts = line.split('\t')
+        if len(parts) < 2:
+            continue
+        level = resolve(prefix + parts[1], hit_path(parts[0])) if OVERRIDES else ''
+        if level != 'off':
+            print(f"{line}\t{level}")
+    sys.exit(0)
+
+for raw in sys.stdin:
+    parts = raw.rstrip('\n').split('\t')
+    if len(parts) < 3 or not parts[0]:
+        continue
+    rule, count, samples = parts[0], parts[1], parts[2]
+    sep = ', ' if ', ' in samples else ','
+    hits = [h.strip() for h in HIT_SPLIT.split(samples) if h.strip()]
+    if not OVERRIDES or not hits:
+        print(f"{rule}\t{count}\t{sep.join(hits[:SAMPLE_LIMIT])}\t")
+        continue
+    groups = {}
+    for hit in hits:
+        level = resolve(rule, hit_path(hit))
+        if level != 'off':
+            groups.setdefault(level, []).append(hit)
+    for level, items in groups.items():
+        print(f"{rule}\t{len(items)}\t{sep.join(items[:SAMPLE_LIMIT])}\t{level}")
+PY
+)" "$@"
+}
+
+# Severity for a row from apply_rule_overrides: an empty level keeps the rule
+# default, `<=LEVEL` (a path's blanket `severity:`) lowers the default to LEVEL
+# but never raises it, and any other level (a `rules:` entry) replaces it.
+override_severity() {
+  local level="$1" default="$2"
+  case "$level" in
+    "") printf '%s' "$default" ;;
+    "<="*)
+      local cap="${level#<=}"
+      local -A rank=([info]=1 [warning]=2 [critical]=3)
+      if [[ ${rank[$default]:-0} -gt ${rank[$cap]:-0} ]]; then printf '%s' "$cap"; else printf '%s' "$default"; fi
+      ;;
+    *) printf '%s' "$level" ;;
+  esac
+}
+
 # Resource lifecycle correlation helper (optional)
 run_resource_lifecycle_checks() {
   local helper="$SCRIPT_DIR/helpers/resource_lifecycle_go.go"
@@ -556,28 +906,90 @@ run_resource_lifecycle_checks() {
     print_finding "info" 0 "Go toolchain unavailable" "Install Go to run the AST helper"
     return
   fi
-  local output helper_err helper_err_tmp helper_err_preview
+  local output helper_err helper_err_tmp helper_err_preview helper_rc=0 helper_status="" parse_failures=""
+  local helper_mode="-progress=never" coverage="" skipped_paths="" catalog pool=""
+  local -a helper_args=()
   helper_err="/dev/null"
   if helper_err_tmp="$(mktemp -t ubs-go-resource-lifecycle.XXXXXX 2>/dev/null || mktemp)"; then
     helper_err="$helper_err_tmp"
   fi
-  if ! output=$(go run "$helper" -- "$PROJECT_DIR" 2>"$helper_err"); then
+  # -v asks the helper what it covered: per-file timing and skipped paths. -q
+  # leaves its stderr to parse errors, which are still read below. Otherwise a
+  # progress bar is drawn when this module's stderr is a terminal (ubs itself
+  # collects module stderr in a file, so only direct runs show it).
+  if [[ "$VERBOSE" -eq 1 ]]; then
+    helper_mode="-verbose"
+  elif [[ "$QUIET" -eq 1 ]]; then
+    helper_mode="-quiet"
+  elif [[ -t 2 && "$CI_MODE" -eq 0 && "$FORMAT" == "text" && "$helper_err" != "/dev/null" ]]; then
+    helper_mode="-progress=always"
+  fi
+  helper_args+=("$helper_mode")
+  # The helper streams the walk; --memory-limit (UBS_MEMORY_LIMIT) caps its heap
+  # and the worker pool, which otherwise follows --jobs.
+  if [[ -n "${UBS_MEMORY_LIMIT:-}" ]]; then helper_args+=("-memory-limit=$UBS_MEMORY_LIMIT"); fi
+  if [[ "${JOBS:-0}" -gt 0 ]]; then helper_args+=("-workers=$JOBS"); fi
+  # Locale and message catalogs from .ubscan.yaml (exported by ubs).
+  if [[ -n "${UBS_LOCALE:-}" ]]; then helper_args+=("-locale=$UBS_LOCALE"); fi
+  while IFS= read -r catalog; do
+    if [[ -n "$catalog" ]]; then helper_args+=("-messages=$catalog"); fi
+  done <<<"${UBS_MESSAGE_CATALOGS:-}"
+  if [[ "$helper_mode" == "-progress=always" ]]; then
+    # stderr is copied to the terminal as it arrives; the redraw prefixes
+    # (\r ESC[K) are stripped from the saved copy so its lines parse as usual.
+    output=$( { go run "$helper" "${helper_args[@]}" -- "$PROJECT_DIR" 2>&1 >&3 | tee "$helper_err" >&2
+                exit "${PIPESTATUS[0]}"; } 3>&1 ) || helper_rc=$?
+    sed "s/^.*$(printf '\033')\[K//" "$helper_err" >"$helper_err.lines" 2>/dev/null \
+      && mv "$helper_err.lines" "$helper_err"
+  else
+    output=$(go run "$helper" "${helper_args[@]}" -- "$PROJECT_DIR" 2>"$helper_err") || helper_rc=$?
+  fi
+  if [[ $helper_rc -ne 0 ]]; then
+    # go run exits 1 for any failure and reports the helper's own code as "exit status N"
+    # (1 = findings, 2 = usage, 3 = internal/parse errors); a build failure has no such line.
+    helper_status="$(sed -n 's/^exit status \([0-9][0-9]*\)$/\1/p' "$helper_err" 2>/dev/null | tail -n 1)"
+    parse_failures="$(sed -n 's/^parse error: //p' "$helper_err" 2>/dev/null)"
+  fi
+  if [[ $helper_rc -ne 0 && "$helper_status" != "1" && ( "$helper_status" != "3" || -z "$parse_failures" ) ]]; then
     helper_err_preview="$(head -n 1 "$helper_err" 2>/dev/null || true)"
     [[ -z "$helper_err_preview" ]] && helper_err_preview="Run: go run $helper -- $PROJECT_DIR"
     print_finding "info" 0 "AST helper failed" "$helper_err_preview"
     [[ "$helper_err" != "/dev/null" ]] && rm -f "$helper_err" 2>/dev/null || true
     return
   fi
+  if [[ "$VERBOSE" -eq 1 ]]; then
+    coverage="$(sed -n 's/^done: //p' "$helper_err" 2>/dev/null | tail -n 1)"
+    pool="$(sed -n 's/^\(memory limit .*\)$/\1/p' "$helper_err" 2>/dev/null | head -n 1)"
+    if [[ -n "$coverage" && -n "$pool" ]]; then coverage+=" ($pool)"; fi
+    skipped_paths="$(sed -n 's/^skipped //p' "$helper_err" 2>/dev/null)"
+  fi
   [[ "$helper_err" != "/dev/null" ]] && rm -f "$helper_err" 2>/dev/null || true
+  if [[ -n "$coverage" ]]; then
+    say "  ${DIM}${INFO} Helper coverage: $coverage${RESET}"
+    if [[ -n "$skipped_paths" ]]; then
+      printf '%s\n' "$skipped_paths" | head -n "$DETAIL_LIMIT" | while IFS= read -r skipped_line; do
+        say "    ${DIM}skipped $skipped_line${RESET}"
+      done
+    fi
+  fi
+  if [[ -n "$parse_failures" ]]; then
+    local parse_count
+    parse_count=$(printf '%s\n' "$parse_failures" | wc -l | awk '{print $1+0}')
+    print_finding "warning" "$parse_count" "Go files with syntax errors (resource analysis incomplete)" \
+      "Only the part of each file the parser could recover was checked for leaks; fix the syntax error so the whole file is scanned (e.g., $(printf '%s\n' "$parse_failures" | head -n 3 | paste -sd ';' - | sed 's/;/; /g'))"
+  fi
+  if [[ -n "$output" && -n "${UBS_OVERRIDES:-}" ]] && command -v python3 >/dev/null 2>&1; then
+    output=$(printf '%s\n' "$output" | apply_rule_overrides --per-finding go.resource.)
+  fi
   if [[ -z "$output" ]]; then
     print_finding "good" "All tracked resource acquisitions have matching cleanups"
     return
   fi
-  while IFS=$'\t' read -r location kind message; do
+  while IFS=$'\t' read -r location kind message level; do
     [[ -z "$location" ]] && continue
     local summary="${RESOURCE_LIFECYCLE_SUMMARY[$kind]:-Resource imbalance}"
     local remediation="${RESOURCE_LIFECYCLE_REMEDIATION[$kind]:-Ensure matching cleanup call}"
-    local severity="${RESOURCE_LIFECYCLE_SEVERITY[$kind]:-warning}"
+    local severity; severity=$(override_severity "$level" "${RESOURCE_LIFECYCLE_SEVERITY[$kind]:-warning}")
     local desc="$remediation"
     [[ -n "$message" ]] && desc+=": $message"
     print_finding "$severity" 1 "$summary [$location]" "$desc"
@@ -660,70 +1072,2102 @@ YAML
 import json, sys
 from collections import OrderedDict
 
-path = sys.argv[1]
-stats = OrderedDict()
+path = sys.argv[1]
+stats = OrderedDict()
+
+def iter_match_objs(blob):
+    if isinstance(blob, list):
+        for it in blob:
+            yield from iter_match_objs(it)
+    elif isinstance(blob, dict):
+        # Most ast-grep match objects include rule id + file + range.
+        if ("range" in blob and ("file" in blob or "path" in blob)
+            and (blob.get("id") or blob.get("rule_id") or blob.get("ruleId"))):
+            yield blob
+        for v in blob.values():
+            yield from iter_match_objs(v)
+
+raw = open(path, "r", encoding="utf-8", errors="ignore").read().strip()
+if not raw:
+    sys.exit(0)
+
+root = None
+try:
+    root = json.loads(raw)
+except Exception:
+    # Some versions can stream JSON objects; fall back line-by-line.
+    for line in raw.splitlines():
+        line = line.strip()
+        if not line:
+            continue
+        try:
+            obj = json.loads(line)
+        except json.JSONDecodeError:
+            continue
+        for match in iter_match_objs(obj):
+            rid = match.get("rule_id") or match.get("id") or match.get("ruleId")
+            if not rid:
+                continue
+            rng = match.get("range") or {}
+            start = rng.get("start") or {}
+            line_no = int(start.get("row", 0)) + 1
+            file_path = match.get("file") or match.get("path") or "?"
+            entry = stats.setdefault(rid, {"count": 0, "samples": []})
+            entry["count"] += 1
+            if len(entry["samples"]) < 3:
+                entry["samples"].append(f"{file_path}:{line_no}")
+else:
+    for match in iter_match_objs(root):
+        rid = match.get("rule_id") or match.get("id") or match.get("ruleId")
+        if not rid:
+            continue
+        rng = match.get("range") or {}
+        start = rng.get("start") or {}
+        line_no = int(start.get("row", 0)) + 1
+        file_path = match.get("file") or match.get("path") or "?"
+        entry = stats.setdefault(rid, {"count": 0, "samples": []})
+        entry["count"] += 1
+        if len(entry["samples"]) < 3:
+            entry["samples"].append(f"{file_path}:{line_no}")
+
+for rid, data in stats.items():
+    print(f"{rid}\t{data['count']}\t{','.join(data['samples'])}")
+PY
+)
+  rm -f "$tmp_json"
+  if [[ $printed -eq 0 ]]; then print_finding "good" "All goroutines handle errors explicitly"; fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Unbounded resource growth (package-level caches, goroutine-per-request)
+# ────────────────────────────────────────────────────────────────────────────
+run_unbounded_growth_checks() {
+  print_subheader "Unbounded memory growth heuristics"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable unbounded growth checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${GROWTH_SEVERITY[$rule_id]:-warning}")
+    local summary=${GROWTH_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${GROWTH_REMEDIATION[$rule_id]:-"Bound long-lived state"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+HANDLER_SIG_RE = re.compile(
+    r'\bfunc\b[^{]*\(\s*[^)]*(?:http\.ResponseWriter|\*gin\.Context|echo\.Context|\*fiber\.Ctx)'
+)
+PKG_COLLECTION_RE = re.compile(
+    r'^(?:var\s+)?(?P<name>[A-Za-z_]\w*)\s*'
+    r'(?:(?P<type>map\[[^\]]+\][^=]+|\[\][^=]+|sync\.Map)\s*$'
+    r'|(?:(?:map\[[^\]]+\][^=]*|\[\][^=]*))?=\s*(?:make\(\s*(?:map\[|\[\])|map\[[^\]]+\][^{]*\{\s*\}|\[\][^{]*\{\s*\}))'
+)
+SYNC_MAP_DECL_RE = re.compile(r'^(?:var\s+)?(?P<name>[A-Za-z_]\w*)\s+(?:sync\.Map|\*sync\.Map)\s*$')
+GO_STMT_RE = re.compile(r'^\s*go\s+(?:func\b|[A-Za-z_][\w.]*\s*\()')
+BOUNDED_RE = re.compile(
+    r'\.(?:Acquire|TryAcquire)\s*\('
+    r'|\.SetLimit\s*\('
+    r'|<-\s*struct\s*\{\s*\}\s*\{\s*\}'
+    r'|\b(?:sem|semaphore|slots|tokens|limiter|pool|workers?)\w*\s*<-'
+)
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path) and not path.name.endswith('_test.go'):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    i = 0
+    while i < len(line):
+        ch = line[i]
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            i += 1
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+            out.append(ch)
+            i += 1
+            continue
+        if ch == '/' and i + 1 < len(line) and line[i + 1] == '/':
+            break
+        out.append(ch)
+        i += 1
+    return ''.join(out)
+
+def brace_delta(text: str) -> int:
+    return text.count('{') - text.count('}')
+
+def block_end(lines, start_idx, col):
+    depth = 0
+    seen = False
+    for idx in range(start_idx, len(lines)):
+        text = strip_comments(lines[idx])
+        if idx == start_idx:
+            text = text[col:]
+        for ch in text:
+            if ch == '{':
+                depth += 1
+                seen = True
+            elif ch == '}':
+                depth -= 1
+                if seen and depth == 0:
+                    return idx
+    return len(lines) - 1
+
+def package_level_lines(lines):
+    depth = 0
+    in_var_block = False
+    for idx, raw in enumerate(lines):
+        text = strip_comments(raw).strip()
+        if depth == 0:
+            if re.match(r'^var\s*\($', text):
+                in_var_block = True
+            elif in_var_block and text == ')':
+                in_var_block = False
+            elif in_var_block or text.startswith('var '):
+                yield idx, text
+        depth += brace_delta(text)
+        if depth < 0:
+            depth = 0
+
+def has_ignore(lines, idx):
+    return 'ubs:ignore' in lines[idx] or (idx > 0 and 'ubs:ignore' in lines[idx - 1])
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+packages = defaultdict(list)
+for file_path in iter_files(ROOT):
+    try:
+        text = file_path.read_text(encoding='utf-8', errors='ignore')
+    except OSError:
+        continue
+    packages[file_path.parent].append((file_path, text.splitlines()))
+
+issues = defaultdict(list)
+for _, files in sorted(packages.items()):
+    collections = {}
+    for path, lines in files:
+        for idx, text in package_level_lines(lines):
+            match = SYNC_MAP_DECL_RE.match(text) or PKG_COLLECTION_RE.match(text)
+            if match and not has_ignore(lines, idx):
+                collections.setdefault(match.group('name'), (path, idx + 1))
+    handlers = []
+    for path, lines in files:
+        for idx, raw in enumerate(lines):
+            text = strip_comments(raw)
+            sig = HANDLER_SIG_RE.search(text)
+            if sig:
+                end = block_end(lines, idx, sig.start())
+                handlers.append((path, lines, idx, end))
+    evicted = set()
+    if collections:
+        for path, lines in files:
+            for idx, raw in enumerate(lines):
+                text = strip_comments(raw)
+                for name in collections:
+                    if collections[name] == (path, idx + 1):
+                        continue
+                    n = re.escape(name)
+                    if re.search(
+                        rf'\bdelete\(\s*{n}\s*,|\bclear\(\s*{n}\s*\)|\b{n}\.(?:Delete|LoadAndDelete|Clear|Remove|Evict|Purge)\s*\('
+                        rf'|\b{n}\s*=\s*(?:make\(|nil\b|map\[|\[\]|{n}\[[^\]]*:)',
+                        text,
+                    ):
+                        evicted.add(name)
+    written = {}
+    for path, lines, start, end in handlers:
+        body = [strip_comments(raw) for raw in lines[start:end + 1]]
+        for offset, text in enumerate(body):
+            for name in collections:
+                if name in evicted or name in written:
+                    continue
+                n = re.escape(name)
+                if re.search(rf'(?<![\w.]){n}\[[^\]]+\]\s*(?:=|\+=|-=|\+\+)|\b{n}\s*=\s*append\(\s*{n}\b|(?<![\w.]){n}\.(?:Store|LoadOrStore)\s*\(', text):
+                    if not has_ignore(lines, start + offset):
+                        written[name] = (path, start + offset + 1)
+        handler_text = '\n'.join(body)
+        if BOUNDED_RE.search(handler_text):
+            continue
+        for offset, text in enumerate(body[1:], start=1):
+            if GO_STMT_RE.match(text) and not has_ignore(lines, start + offset):
+                issues['go.growth.goroutine-per-request'].append((relpath(path), start + offset + 1))
+                break
+    for name, (path, line) in sorted(written.items(), key=lambda kv: (str(kv[1][0]), kv[1][1])):
+        issues['go.growth.unbounded-cache'].append((relpath(path), line))
+
+for rule_id, hits in issues.items():
+    samples = ','.join(f'{name}:{line}' for name, line in hits)
+    print(f"{rule_id}\t{len(hits)}\t{samples}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "No unbounded handler caches or per-request goroutine fan-out detected"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Time and timezone correctness (monotonic clock, locations, Equal, test sleeps)
+# ────────────────────────────────────────────────────────────────────────────
+run_time_correctness_checks() {
+  print_subheader "Time and timezone correctness"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable time correctness checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${TIME_SEVERITY[$rule_id]:-warning}")
+    local summary=${TIME_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${TIME_REMEDIATION[$rule_id]:-"Review time handling"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+UNIX_CALL = r'\.Unix(?:Nano|Milli|Micro)?\(\)'
+NOW_UNIX_RE = re.compile(r'time\.Now\(\)' + UNIX_CALL)
+UNIX_VAR_RE = re.compile(r'\b(?P<name>[A-Za-z_]\w*)\s*:?=\s*(?:u?int64\()?time\.Now\(\)' + UNIX_CALL)
+UNIX_DIFF_RE = re.compile(UNIX_CALL + r'\)?\s*-\s*(?:u?int64\()?[\w.]+' + UNIX_CALL)
+PARSE_RE = re.compile(r'\btime\.Parse\(\s*(?P<layout>"[^"]*"|`[^`]*`|time\.\w+|[A-Za-z_]\w*)\s*,')
+ZONELESS_CONSTS = {
+    'time.DateTime', 'time.DateOnly', 'time.TimeOnly', 'time.Kitchen',
+    'time.ANSIC', 'time.Stamp', 'time.StampMilli', 'time.StampMicro', 'time.StampNano',
+}
+ZONE_TOKENS = ('Z07', 'Z0700', '-07', 'MST')
+CONST_RE = re.compile(r'^\s*(?:const\s+)?(?P<name>[A-Za-z_]\w*)\s*=\s*(?P<value>"[^"]*"|`[^`]*`)')
+SLEEP_RE = re.compile(r'\btime\.Sleep\s*\(')
+TIME_SOURCE = (
+    r'time\.(?:Now|Date|Unix|UnixMilli|UnixMicro|Parse|ParseInLocation)\('
+    r'|[\w.]+\.(?:Add|AddDate|Truncate|Round|UTC|Local|In)\('
+)
+TIME_VAR_RE = re.compile(r'\b(?P<names>[A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*)\s*:?=\s*(?:' + TIME_SOURCE + r')')
+TIME_DECL_RE = re.compile(r'\b(?P<names>[A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*)\s+\*?time\.Time\b(?!\s*\{)')
+TIME_CALL_OPERAND = re.compile(r'^(?:time\.(?:Now|Date|Unix|UnixMilli|UnixMicro)\(.*\)|[\w.]+\.(?:Add|AddDate|Truncate|Round|UTC|Local|In)\(.*\))$')
+COMPARE_RE = re.compile(r'(?P<lhs>[\w.]+(?:\([^()]*\))?)\s*(?P<op>==|!=)\s*(?P<rhs>[\w.]+(?:\([^()]*\))?)')
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    for i, ch in enumerate(line):
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\' and quote != '`':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+        elif ch == '/' and line[i + 1:i + 2] == '/':
+            break
+        out.append(ch)
+    return ''.join(out)
+
+def has_ignore(lines, idx):
+    return 'ubs:ignore' in lines[idx] or (idx > 0 and 'ubs:ignore' in lines[idx - 1])
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+def layout_has_zone(layout: str, consts) -> bool:
+    if layout in ZONELESS_CONSTS:
+        return False
+    if layout.startswith('time.'):
+        return True
+    if layout[0] not in '"`':
+        layout = consts.get(layout)
+        if layout is None:
+            return True
+    return any(token in layout for token in ZONE_TOKENS) or layout.rstrip('"`').endswith('Z')
+
+def is_time_operand(expr: str, time_vars, time_fields) -> bool:
+    if TIME_CALL_OPERAND.match(expr):
+        return True
+    if '(' in expr:
+        return False
+    if expr in time_vars:
+        return True
+    if '.' in expr and expr.rsplit('.', 1)[1] in time_fields:
+        return True
+    return False
+
+issues = defaultdict(list)
+for path in sorted(iter_files(ROOT)):
+    try:
+        lines = path.read_text(encoding='utf-8', errors='ignore').splitlines()
+    except OSError:
+        continue
+    is_test = path.name.endswith('_test.go')
+    code = [strip_comments(raw) for raw in lines]
+    consts, unix_vars, time_vars, time_fields = {}, set(), set(), set()
+    for text in code:
+        m = CONST_RE.match(text)
+        if m:
+            consts[m.group('name')] = m.group('value')
+        for m in UNIX_VAR_RE.finditer(text):
+            unix_vars.add(m.group('name'))
+        for m in TIME_VAR_RE.finditer(text):
+            time_vars.update(n.strip() for n in m.group('names').split(',') if n.strip() != '_')
+        for m in TIME_DECL_RE.finditer(text):
+            names = [n.strip() for n in m.group('names').split(',')]
+            if re.match(r'^\s*[A-Z]\w*\s+\*?time\.Time\b', text):
+                time_fields.update(names)
+            time_vars.update(names)
+    time_vars.discard('err')
+    unix_var_diff = None
+    if unix_vars:
+        alt = '|'.join(re.escape(n) for n in sorted(unix_vars))
+        unix_var_diff = re.compile(
+            rf'(?:{UNIX_CALL}|\b(?:{alt})\b)\s*-\s*\b(?:{alt})\b|\b(?:{alt})\b\s*-\s*(?:time\.Now\(\))?' + UNIX_CALL
+            + rf'|time\.Now\(\){UNIX_CALL}\s*-\s*\w'
+        )
+    for idx, text in enumerate(code):
+        if has_ignore(lines, idx):
+            continue
+        loc = (relpath(path), idx + 1)
+        if UNIX_DIFF_RE.search(text) or (unix_var_diff and unix_var_diff.search(text)) \
+                or re.search(r'time\.Now\(\)' + UNIX_CALL + r'\s*-\s*[A-Za-z_]', text):
+            issues['go.time.wall-clock-elapsed'].append(loc)
+        m = PARSE_RE.search(text)
+        if m and not layout_has_zone(m.group('layout'), consts):
+            issues['go.time.parse-no-location'].append(loc)
+        if is_test and SLEEP_RE.search(text):
+            issues['go.time.sleep-in-test'].append(loc)
+        for m in COMPARE_RE.finditer(text):
+            lhs, rhs = m.group('lhs'), m.group('rhs')
+            if 'nil' in (lhs, rhs) or lhs.endswith('Time{') or rhs.startswith('time.Time'):
+                continue
+            if is_time_operand(lhs, time_vars, time_fields) or is_time_operand(rhs, time_vars, time_fields):
+                issues['go.time.equality'].append(loc)
+                break
+
+for rule_id, hits in issues.items():
+    samples = ','.join(f'{name}:{line}' for name, line in hits)
+    print(f"{rule_id}\t{len(hits)}\t{samples}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "No wall-clock elapsed math, zone-less parses, test sleeps, or time.Time == comparisons detected"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Floating-point and money arithmetic (equality, accumulation, truncation)
+# ────────────────────────────────────────────────────────────────────────────
+run_numeric_money_checks() {
+  print_subheader "Floating-point and money arithmetic"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable floating-point checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${NUMERIC_SEVERITY[$rule_id]:-warning}")
+    local summary=${NUMERIC_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${NUMERIC_REMEDIATION[$rule_id]:-"Review floating-point arithmetic"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+MONEY_RE = re.compile(r'(?i)(?:price|amount|balance|total|cost|fee|tax|subtotal|payment|charge|refund|discount|revenue|salary|wage)')
+FLOAT_TYPE = r'(?:\*?float(?:32|64))'
+FLOAT_LIT = r'(?<![\w.])(?:\d+\.\d*|\.\d+|\d+(?:\.\d*)?[eE][+-]?\d+)(?![\w.])'
+FLOAT_DECL_RE = re.compile(
+    r'(?P<names>[A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*)\s+' + FLOAT_TYPE + r'\b(?!\s*\()'
+)
+FLOAT_ASSIGN_RE = re.compile(
+    r'\b(?P<name>[A-Za-z_]\w*)\s*:=\s*(?:' + FLOAT_LIT + r'|float(?:32|64)\(|math\.\w+\()'
+)
+FIELD_DECL_RE = re.compile(r'^\s*(?P<names>[A-Z]\w*(?:\s*,\s*[A-Z]\w*)*)\s+' + FLOAT_TYPE + r'\b')
+OPERAND = r'[\w.]+(?:\([^()]*\))?'
+COMPARE_RE = re.compile(r'(?P<lhs>' + OPERAND + r')\s*(?P<op>==|!=)\s*(?P<rhs>' + OPERAND + r'|-?' + FLOAT_LIT + r')')
+ZERO_RE = re.compile(r'^-?0(?:\.0*)?$')
+ACCUM_RE = re.compile(r'^\s*(?P<target>[\w.\[\]]+)\s*(?:\+=|-=|\*=)|^\s*(?P<target2>[\w.\[\]]+)\s*=\s*(?P=target2)\s*[-+*]')
+INT_CONV_RE = re.compile(r'\bu?int(?:8|16|32|64)?\(\s*(?P<arg>[^()]*(?:\([^()]*\)[^()]*)*)\)')
+SCALE_RE = re.compile(r'\*\s*(?:100|1000|1e2|1e3|100\.0)\b')
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    for i, ch in enumerate(line):
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\' and quote != '`':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+        elif ch == '/' and line[i + 1:i + 2] == '/':
+            break
+        out.append(ch)
+    return ''.join(out)
+
+def strip_strings(text: str) -> str:
+    return re.sub(r'"(?:\\.|[^"\\])*"|`[^`]*`|\'(?:\\.|[^\'\\])*\'', '""', text)
+
+def has_ignore(lines, idx):
+    return 'ubs:ignore' in lines[idx] or (idx > 0 and 'ubs:ignore' in lines[idx - 1])
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+def last_ident(expr: str) -> str:
+    expr = re.sub(r'\[[^\]]*\]$', '', expr)
+    return expr.rsplit('.', 1)[-1]
+
+def is_float_expr(expr, float_vars, float_fields) -> bool:
+    if re.fullmatch(r'-?' + FLOAT_LIT, expr):
+        return True
+    if re.match(r'^(?:float(?:32|64)\(|math\.(?:Sqrt|Pow|Abs|Floor|Ceil|Round|Trunc|Mod|Log\w*|Exp)\()', expr):
+        return True
+    if '(' in expr:
+        return False
+    if '.' in expr:
+        return last_ident(expr) in float_fields
+    return expr in float_vars
+
+issues = defaultdict(list)
+for path in sorted(iter_files(ROOT)):
+    try:
+        lines = path.read_text(encoding='utf-8', errors='ignore').splitlines()
+    except OSError:
+        continue
+    code = [strip_strings(strip_comments(raw)) for raw in lines]
+    float_vars, float_fields = set(), set()
+    for text in code:
+        m = FIELD_DECL_RE.match(text)
+        if m:
+            float_fields.update(n.strip() for n in m.group('names').split(','))
+        for m in FLOAT_DECL_RE.finditer(text):
+            float_vars.update(n.strip() for n in m.group('names').split(','))
+        for m in FLOAT_ASSIGN_RE.finditer(text):
+            float_vars.add(m.group('name'))
+    float_vars -= {'var', 'const', 'func', 'return', 'type'}
+
+    loop_stack, depth = [], 0
+    for idx, text in enumerate(code):
+        if re.match(r'^\s*(?:\}\s*)?for\b', text) and '{' in text:
+            loop_stack.append(depth)
+        in_loop = bool(loop_stack)
+        depth += text.count('{') - text.count('}')
+        while loop_stack and depth <= loop_stack[-1]:
+            loop_stack.pop()
+        if has_ignore(lines, idx):
+            continue
+        loc = (relpath(path), idx + 1)
+
+        for m in COMPARE_RE.finditer(text):
+            lhs, rhs = m.group('lhs'), m.group('rhs')
+            if 'nil' in (lhs, rhs) or ZERO_RE.match(lhs) or ZERO_RE.match(rhs) or lhs == rhs:
+                continue
+            if is_float_expr(lhs, float_vars, float_fields) or is_float_expr(rhs, float_vars, float_fields):
+                issues['go.float.equality'].append(loc)
+                break
+
+        if in_loop:
+            m = ACCUM_RE.match(text)
+            if m:
+                target = m.group('target') or m.group('target2')
+                name = last_ident(target)
+                typed = name in float_fields if '.' in target else name in float_vars
+                if typed and MONEY_RE.search(name):
+                    issues['go.money.float-accumulation'].append(loc)
+
+        for m in INT_CONV_RE.finditer(text):
+            arg = m.group('arg').strip()
+            if not arg or arg.startswith('math.Round'):
+                continue
+            idents = re.findall(r'[A-Za-z_][\w.]*', arg)
+            floaty = any(is_float_expr(i, float_vars, float_fields) for i in idents) or re.search(FLOAT_LIT, arg)
+            moneyish = any(MONEY_RE.search(last_ident(i)) for i in idents)
+            if floaty and (moneyish or SCALE_RE.search(arg)):
+                issues['go.money.float-to-int-truncation'].append(loc)
+                break
+
+for rule_id, hits in issues.items():
+    samples = ','.join(f'{name}:{line}' for name, line in hits)
+    print(f"{rule_id}\t{len(hits)}\t{samples}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "No float equality, float money accumulation, or truncating cent conversions detected"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# gRPC streaming Send/Recv error handling
+# ────────────────────────────────────────────────────────────────────────────
+run_grpc_stream_checks() {
+  print_subheader "gRPC stream Send/Recv errors"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable gRPC stream checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${GRPC_STREAM_SEVERITY[$rule_id]:-warning}")
+    local summary=${GRPC_STREAM_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${GRPC_STREAM_REMEDIATION[$rule_id]:-"Handle stream errors"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+STREAM_TYPE = (r'(?:\*?(?:[A-Za-z_]\w*\.)?[A-Z]\w*_[A-Z]\w*(?:Server|Client)\b|'
+               r'grpc\.(?:ServerStream|ClientStream)\b|'
+               r'grpc\.(?:BidiStreaming|ServerStreaming|ClientStreaming)(?:Server|Client)\b)')
+TYPED_RE = re.compile(r'\b([A-Za-z_]\w*)\s+' + STREAM_TYPE)
+CALL_ASSIGN_RE = re.compile(r'\b([A-Za-z_]\w*)\s*,\s*[A-Za-z_]\w*\s*:?=\s*[\w.]+\.[A-Z]\w*\(')
+STREAM_ONLY_RE = r'\.(?:CloseSend|CloseAndRecv|SendAndClose|Recv)\(\s*\)'
+SEND_METHODS = r'(?:Send|SendMsg|SendHeader|SendAndClose)'
+RECV_METHODS = r'(?:Recv|RecvMsg|CloseAndRecv)'
+EOF_ONLY_RE = re.compile(r'\b\w+\s*[!=]=\s*io\.EOF\b|\berrors\.Is\(\s*\w+\s*,\s*io\.EOF\s*\)')
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path) and not path.name.endswith('.pb.go'):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    for i, ch in enumerate(line):
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\' and quote != '`':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+        elif ch == '/' and line[i + 1:i + 2] == '/':
+            break
+        out.append(ch)
+    return ''.join(out)
+
+def strip_strings(text: str) -> str:
+    return re.sub(r'"(?:\\.|[^"\\])*"|`[^`]*`|\'(?:\\.|[^\'\\])*\'', '""', text)
+
+def has_ignore(lines, idx):
+    return 'ubs:ignore' in lines[idx] or (idx > 0 and 'ubs:ignore' in lines[idx - 1])
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+def stream_names(code, joined):
+    # Typed parameters/fields, client-call results later used as a stream, and
+    # anything named like a stream that calls Send/Recv.
+    names = {m.group(1) for text in code for m in TYPED_RE.finditer(text)}
+    for text in code:
+        for m in CALL_ASSIGN_RE.finditer(text):
+            if re.search(r'\b' + re.escape(m.group(1)) + STREAM_ONLY_RE, joined):
+                names.add(m.group(1))
+    for n in re.findall(r'\b([A-Za-z_]\w*)\.(?:' + SEND_METHODS + '|' + RECV_METHODS + r')\(', joined):
+        if 'stream' in n.lower():
+            names.add(n)
+    names.discard('_')
+    return names
+
+def err_handled(code, start, err, stream):
+    # The Recv error counts as handled once it is used for anything other than
+    # an io.EOF comparison before the next Recv on the same stream.
+    for text in code[start + 1:start + 12]:
+        if re.search(r'\b' + re.escape(stream) + r'\.' + RECV_METHODS + r'\(', text):
+            break
+        if re.search(r'\b' + re.escape(err) + r'\b', EOF_ONLY_RE.sub('', text)):
+            return True
+    return False
+
+issues = defaultdict(list)
+for path in sorted(iter_files(ROOT)):
+    try:
+        raw = path.read_text(encoding='utf-8', errors='ignore')
+    except OSError:
+        continue
+    if 'grpc' not in raw and not re.search(r'_[A-Z]\w*(?:Server|Client)\b', raw):
+        continue
+    lines = raw.splitlines()
+    code = [strip_strings(strip_comments(line)) for line in lines]
+    names = stream_names(code, '\n'.join(code))
+    if not names:
+        continue
+    alt = '|'.join(re.escape(n) for n in sorted(names))
+    bare_send = re.compile(r'^\s*(?:go\s+|defer\s+)?(?:_\s*=\s*)?(?:' + alt + r')\.' + SEND_METHODS + r'\(')
+    bare_recv = re.compile(r'^\s*(?:go\s+|defer\s+)?(?:' + alt + r')\.' + RECV_METHODS + r'\(')
+    blank_recv = re.compile(r'^\s*(?:[\w.]+\s*,\s*)?_\s*:?=\s*(?:' + alt + r')\.' + RECV_METHODS + r'\(')
+    err_recv = re.compile(r'^\s*(?:[\w.]+\s*,\s*)?([A-Za-z_]\w*)\s*:?=\s*(' + alt + r')\.' + RECV_METHODS + r'\(')
+    for idx, text in enumerate(code):
+        if has_ignore(lines, idx):
+            continue
+        loc = (relpath(path), idx + 1)
+        if bare_send.match(text):
+            issues['go.grpc.stream-send-error-ignored'].append(loc)
+        elif bare_recv.match(text) or blank_recv.match(text):
+            issues['go.grpc.stream-recv-error-ignored'].append(loc)
+        else:
+            m = err_recv.match(text)
+            if m and not err_handled(code, idx, m.group(1), m.group(2)):
+                issues['go.grpc.stream-recv-error-ignored'].append(loc)
+
+for rule_id in ('go.grpc.stream-recv-error-ignored', 'go.grpc.stream-send-error-ignored'):
+    hits = issues.get(rule_id)
+    if hits:
+        samples = ','.join(f'{name}:{line}' for name, line in hits)
+        print(f"{rule_id}\t{len(hits)}\t{samples}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "gRPC stream Send/Recv errors are checked"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# init() work and package-level mutable state
+# ────────────────────────────────────────────────────────────────────────────
+run_package_state_checks() {
+  print_subheader "init() side effects and package-level state"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable package state checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${PACKAGE_STATE_SEVERITY[$rule_id]:-warning}")
+    local summary=${PACKAGE_STATE_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${PACKAGE_STATE_REMEDIATION[$rule_id]:-"Move side effects out of package initialization"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+FUNC_RE = re.compile(r'^func\s*(\([^)]*\))?\s*([A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\((.*)')
+PACKAGE_RE = re.compile(r'^package\s+(\w+)')
+HEAVY_CALL_RE = re.compile(
+    r'\b(?:http\.(?:Get|Post|PostForm|Head|NewRequest\w*)|\w+\.Do|net\.Dial\w*|tls\.Dial|grpc\.(?:Dial\w*|NewClient)|'
+    r'sql\.Open|\w+\.Ping(?:Context)?|os\.(?:ReadFile|Open|OpenFile|ReadDir|Create|WriteFile)|ioutil\.(?:ReadFile|ReadAll|ReadDir)|'
+    r'filepath\.Walk(?:Dir)?|exec\.Command(?:Context)?|time\.Sleep)\(')
+MUTABLE_DECL_RE = re.compile(r'^\s*(?:var\s+)?([A-Za-z_]\w*)\s*(?:(?:map\[|\[\])[^=]*$|(?:(?:map\[|\[\])[^=]*)?=\s*(?:map\[|\[\]|make\(\s*(?:map\[|\[\])))')
+LOCK_RE = re.compile(r'\.(?:R?Lock|TryR?Lock)\(\)')
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path) and not path.name.endswith('_test.go'):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    for i, ch in enumerate(line):
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\' and quote != '`':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+        elif ch == '/' and line[i + 1:i + 2] == '/':
+            break
+        out.append(ch)
+    return ''.join(out)
+
+def strip_strings(text: str) -> str:
+    return re.sub(r'"(?:\\.|[^"\\])*"|`[^`]*`|\'(?:\\.|[^\'\\])*\'', '""', text)
+
+def has_ignore(lines, idx):
+    return 'ubs:ignore' in lines[idx] or (idx > 0 and 'ubs:ignore' in lines[idx - 1])
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+def scan_top_level(code):
+    """Split a file into package-level var lines and func bodies."""
+    funcs, globals_, depth, in_var_block, idx = [], {}, 0, False, 0
+    while idx < len(code):
+        text = code[idx]
+        m = FUNC_RE.match(text)
+        if m and depth == 0:
+            d, end, opened = 0, len(code) - 1, False
+            for j in range(idx, len(code)):
+                d += code[j].count('{') - code[j].count('}')
+                opened = opened or '{' in code[j]
+                if opened and d <= 0:
+                    end = j
+                    break
+            funcs.append((m.group(2), bool(m.group(1)), m.group(3), idx, end))
+            idx = end + 1
+            continue
+        stripped = text.strip()
+        if depth == 0 and re.match(r'^var\s*\($', stripped):
+            in_var_block = True
+        elif in_var_block and depth == 0 and stripped == ')':
+            in_var_block = False
+        elif depth == 0 and (in_var_block or stripped.startswith('var ')):
+            g = MUTABLE_DECL_RE.match(text)
+            if g:
+                globals_[g.group(1)] = idx
+        depth += text.count('{') - text.count('}')
+        idx += 1
+    return funcs, globals_
+
+issues = defaultdict(list)
+for path in sorted(iter_files(ROOT)):
+    try:
+        lines = path.read_text(encoding='utf-8', errors='ignore').splitlines()
+    except OSError:
+        continue
+    code = [strip_strings(strip_comments(raw)) for raw in lines]
+    pkg = next((m.group(1) for m in map(PACKAGE_RE.match, code) if m), '')
+    rel = relpath(path)
+    funcs, globals_ = scan_top_level(code)
+    for name, is_method, params, start, end in funcs:
+        body = code[start + 1:end + 1]
+        is_init = name == 'init' and not is_method
+        locked = any(LOCK_RE.search(text) for text in body)
+        shadowed = {p for p in re.findall(r'\b([A-Za-z_]\w*)\s+[\w.*\[\]]', params)}
+        for off, text in enumerate(body):
+            idx = start + 1 + off
+            if has_ignore(lines, idx):
+                continue
+            shadowed.update(re.findall(r'\b([A-Za-z_]\w*)\s*:=', text))
+            if re.search(r'\bflag\.Parse\(\)', text) and (is_init or pkg != 'main'):
+                issues['go.flag.parse-outside-main'].append(f'{rel}:{idx + 1}')
+            if is_init:
+                call = HEAVY_CALL_RE.search(text)
+                if call:
+                    issues['go.init.heavy-work'].append(f'{rel}:{idx + 1} ({call.group(0)[:-1]})')
+                continue
+            if locked:
+                continue
+            for g in globals_:
+                if g in shadowed:
+                    continue
+                ge = re.escape(g)
+                if (re.match(r'^\s*' + ge + r'\[[^\]]+\]\s*(?:[-+*/|&]?=|\+\+|--)', text)
+                        or re.search(r'\bdelete\(\s*' + ge + r'\s*,', text)
+                        or re.match(r'^\s*' + ge + r'\s*=\s*append\(\s*' + ge + r'\b', text)):
+                    issues['go.global.unsynchronized-mutation'].append(f'{rel}:{idx + 1} ({g})')
+                    break
+
+for rule_id in ('go.init.heavy-work', 'go.global.unsynchronized-mutation', 'go.flag.parse-outside-main'):
+    hits = issues.get(rule_id)
+    if hits:
+        print(f"{rule_id}\t{len(hits)}\t{', '.join(hits)}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "init() stays cheap and package-level state is synchronized"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# sync/atomic and lock-copy misuse
+# ────────────────────────────────────────────────────────────────────────────
+run_atomic_misuse_checks() {
+  print_subheader "Atomic access and sync value copies"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable atomic misuse checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${ATOMIC_SEVERITY[$rule_id]:-warning}")
+    local summary=${ATOMIC_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${ATOMIC_REMEDIATION[$rule_id]:-"Keep every access to shared state atomic or under one lock"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+FUNC_RE = re.compile(r'^func\s*(\(([^)]*)\))?\s*([A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\((.*)')
+ATOMIC_FN = r'atomic\.(?:Add|Load|Store|Swap|CompareAndSwap|And|Or)(?:Int32|Int64|Uint32|Uint64|Uintptr|Pointer)'
+ATOMIC_CALL_RE = re.compile(ATOMIC_FN + r'\(\s*&\s*([A-Za-z_][\w.]*)')
+ATOMIC_LOAD_RE = re.compile(r'atomic\.Load\w+\(\s*&\s*([A-Za-z_][\w.]*)\s*\)|\b([A-Za-z_][\w.]*)\.Load\(\)')
+ATOMIC_STORE_RE = re.compile(r'atomic\.Store\w+\(\s*&\s*([A-Za-z_][\w.]*)\s*,|\b([A-Za-z_][\w.]*)\.Store\([^,()]*(?:\([^()]*\))?[^,()]*\)')
+ATOMIC_CAS_RE = re.compile(r'atomic\.CompareAndSwap\w+\(\s*&\s*([A-Za-z_][\w.]*)|\b([A-Za-z_][\w.]*)\.CompareAndSwap\(')
+SYNC_VALUE_RE = re.compile(
+    r'^\s*(?:[A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*\s+)?(?:sync\.(?:Mutex|RWMutex|WaitGroup|Once|Cond)|'
+    r'atomic\.(?:Int32|Int64|Uint32|Uint64|Uintptr|Bool|Value|Pointer\[[^\]]+\]))\b')
+STRUCT_RE = re.compile(r'^\s*(?:type\s+)?([A-Za-z_]\w*)\s+struct\s*\{')
+LOCK_RE = re.compile(r'\.(?:R?Lock|TryR?Lock)\(\)')
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path) and not path.name.endswith('_test.go'):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    for i, ch in enumerate(line):
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\' and quote != '`':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+        elif ch == '/' and line[i + 1:i + 2] == '/':
+            break
+        out.append(ch)
+    return ''.join(out)
+
+def strip_strings(text: str) -> str:
+    return re.sub(r'"(?:\\.|[^"\\])*"|`[^`]*`|\'(?:\\.|[^\'\\])*\'', '""', text)
+
+def has_ignore(lines, idx):
+    return 'ubs:ignore' in lines[idx] or (idx > 0 and 'ubs:ignore' in lines[idx - 1])
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+def func_spans(code):
+    spans, idx = [], 0
+    while idx < len(code):
+        m = FUNC_RE.match(code[idx])
+        if not m:
+            idx += 1
+            continue
+        d, end, opened = 0, len(code) - 1, False
+        for j in range(idx, len(code)):
+            d += code[j].count('{') - code[j].count('}')
+            opened = opened or '{' in code[j]
+            if opened and d <= 0:
+                end = j
+                break
+        spans.append((m.group(2) or '', m.group(3), m.group(4), idx, end))
+        idx = end + 1
+    return spans
+
+def struct_fields(code):
+    """Map struct type name -> list of field lines (single-level, brace matched)."""
+    structs, idx = {}, 0
+    while idx < len(code):
+        m = STRUCT_RE.match(code[idx])
+        if not m or code[idx].strip().startswith('func'):
+            idx += 1
+            continue
+        depth, fields = code[idx].count('{') - code[idx].count('}'), []
+        j = idx + 1
+        while j < len(code) and depth > 0:
+            if depth == 1:
+                fields.append(code[j])
+            depth += code[j].count('{') - code[j].count('}')
+            j += 1
+        structs[m.group(1)] = fields
+        idx = j
+    return structs
+
+def target_of(match):
+    return next(g for g in match.groups() if g)
+
+packages = defaultdict(list)
+for path in sorted(iter_files(ROOT)):
+    try:
+        lines = path.read_text(encoding='utf-8', errors='ignore').splitlines()
+    except OSError:
+        continue
+    packages[path.parent].append((path, lines, [strip_strings(strip_comments(raw)) for raw in lines]))
+
+issues = defaultdict(list)
+for pkg_files in packages.values():
+    # Struct types that must not be copied: a sync/atomic value field, or a
+    # by-value field of another such struct.
+    structs = {}
+    for _, _, code in pkg_files:
+        structs.update(struct_fields(code))
+    nocopy = {name for name, fields in structs.items() if any(SYNC_VALUE_RE.match(f) for f in fields)}
+    changed = True
+    while changed:
+        changed = False
+        for name, fields in structs.items():
+            if name in nocopy:
+                continue
+            if any(re.match(r'^\s*(?:[A-Za-z_]\w*\s+)?(?:' + '|'.join(map(re.escape, nocopy)) + r')\s*$', f) for f in fields if nocopy):
+                nocopy.add(name)
+                changed = True
+    nocopy_alt = '|'.join(map(re.escape, sorted(nocopy)))
+
+    # Everything reached through a sync/atomic function in this package.
+    atomic_targets = {}
+    for _, _, code in pkg_files:
+        for text in code:
+            for m in ATOMIC_CALL_RE.finditer(text):
+                target = m.group(1)
+                key = target.rsplit('.', 1)[-1]
+                atomic_targets.setdefault(key, '.' in target)
+
+    for path, lines, code in pkg_files:
+        rel = relpath(path)
+        slice_vars = set()
+        if nocopy:
+            for text in code:
+                for m in re.finditer(r'\b([A-Za-z_]\w*)\s*(?::=|=)?\s*(?:make\(\s*)?(?:\[\]|map\[[^\]]+\])(?:' + nocopy_alt + r')\b', text):
+                    slice_vars.add(m.group(1))
+        in_struct = 0
+        for idx, text in enumerate(code):
+            if in_struct or STRUCT_RE.match(text):
+                in_struct += text.count('{') - text.count('}')
+                continue
+            if has_ignore(lines, idx) or not atomic_targets:
+                continue
+            plain = ATOMIC_CALL_RE.sub('', text)
+            if re.match(r'^\s*var\b', plain):
+                continue
+            for key, is_field in atomic_targets.items():
+                pattern = (r'\.' if is_field else r'(?<![\w.&])') + re.escape(key) + r'\b(?!\s*:[^=])'
+                if re.search(pattern, plain) and not re.match(r'^\s*' + re.escape(key) + r'\s+[\w.*\[\]]+\s*$', plain):
+                    issues['go.atomic.mixed-access'].append(f'{rel}:{idx + 1} ({key})')
+                    break
+
+        for recv, name, params, start, end in func_spans(code):
+            if has_ignore(lines, start):
+                continue
+            if nocopy:
+                rm = re.match(r'^\s*\w*\s*(' + nocopy_alt + r')\s*$', recv)
+                if rm:
+                    issues['go.sync.lock-copy'].append(f'{rel}:{start + 1} (value receiver {rm.group(1)})')
+                head = params.split(')', 1)[0]
+                for pm in re.finditer(r'(?:^|,)\s*(?:[A-Za-z_]\w*\s+)?(' + nocopy_alt + r')\s*(?=,|$)', head):
+                    issues['go.sync.lock-copy'].append(f'{rel}:{start + 1} (parameter {pm.group(1)})')
+                for off in range(start + 1, end + 1):
+                    if has_ignore(lines, off):
+                        continue
+                    rg = re.search(r'\bfor\s+\w+\s*,\s*\w+\s*:?=\s*range\s+(?:[\w.]*\.)?(\w+)\s*\{', code[off])
+                    if rg and rg.group(1) in slice_vars:
+                        issues['go.sync.lock-copy'].append(f'{rel}:{off + 1} (range value over {rg.group(1)})')
+            body = code[start + 1:end + 1]
+            if any(LOCK_RE.search(text) for text in body):
+                continue
+            loaded = {}
+            for off, text in enumerate(body):
+                idx = start + 1 + off
+                for m in ATOMIC_CAS_RE.finditer(text):
+                    loaded.pop(target_of(m), None)
+                for m in ATOMIC_STORE_RE.finditer(text):
+                    target = target_of(m)
+                    if target in loaded and not has_ignore(lines, idx):
+                        issues['go.atomic.check-then-act'].append(
+                            f'{rel}:{idx + 1} ({target} loaded at line {loaded.pop(target)})')
+                for m in ATOMIC_LOAD_RE.finditer(text):
+                    loaded.setdefault(target_of(m), idx + 1)
+
+for rule_id in ('go.atomic.mixed-access', 'go.sync.lock-copy', 'go.atomic.check-then-act'):
+    hits = issues.get(rule_id)
+    if hits:
+        print(f"{rule_id}\t{len(hits)}\t{', '.join(hits)}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "Atomic values are only touched atomically and sync values are not copied"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Generated code provenance and drift
+# ────────────────────────────────────────────────────────────────────────────
+# Recognizes protoc-gen-go, mockgen, and stringer output by its
+# "Code generated ... DO NOT EDIT." header. Staleness is judged from content
+# (proto fields / enum constants missing from the output) and from change
+# times: last commit time for clean tracked files, mtime otherwise.
+run_generated_code_checks() {
+  print_subheader "Generated file provenance (protobuf, mockgen, stringer)"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable generated code checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${GENERATED_SEVERITY[$rule_id]:-warning}")
+    local summary=${GENERATED_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${GENERATED_REMEDIATION[$rule_id]:-"Regenerate the file instead of editing it"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import hashlib, re, subprocess, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+CHECKSUM_FILE = '.ubs-generated.sha256'
+
+HEADER_RE = re.compile(r'^// Code generated (.*) DO NOT EDIT\.$')
+PACKAGE_RE = re.compile(r'^package\s+\w+')
+FUNC_RE = re.compile(r'^func\s*(?:\(\s*(?:\w+\s+)?\*?\s*(\w+)(?:\[[^\]]*\])?\s*\))?\s*(\w+)')
+TYPE_RE = re.compile(r'^type\s+(\w+)\b')
+PB_METHODS = re.compile(r'^(?:Reset|String|ProtoMessage|ProtoReflect|Descriptor|Enum|Type|Number|EnumDescriptor|'
+                        r'UnmarshalJSON|Get[A-Z_]\w*|is[A-Z]\w*|XXX_\w+)$')
+PB_FUNCS = re.compile(r'^(?:init|file_\w+)$')
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path):
+            yield path
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+def read_lines(path: Path):
+    try:
+        return path.read_text(encoding='utf-8', errors='ignore').splitlines()
+    except OSError:
+        return []
+
+def generator_header(lines):
+    """Return (generator text, header line, package line) for a generated file."""
+    package_at = next((i for i, text in enumerate(lines) if PACKAGE_RE.match(text)), len(lines))
+    for i, text in enumerate(lines):
+        m = HEADER_RE.match(text.strip())
+        if m:
+            return m.group(1), i, package_at
+    return None, None, package_at
+
+def header_value(lines, key):
+    for text in lines[:40]:
+        m = re.match(r'^//\s*' + key + r':\s*(.+?)\s*$', text)
+        if m:
+            return m.group(1)
+    return ''
+
+GIT_TOP, GIT_DIRTY = None, set()
+try:
+    GIT_TOP = Path(subprocess.run(['git', '-C', str(BASE_DIR), 'rev-parse', '--show-toplevel'], capture_output=True,
+                                  text=True, check=True, timeout=30).stdout.strip()).resolve()
+    status = subprocess.run(['git', '-C', str(GIT_TOP), 'status', '--porcelain', '-z', '--untracked-files=all'],
+                            capture_output=True, text=True, check=True, timeout=60).stdout
+    GIT_DIRTY = {str((GIT_TOP / entry[3:]).resolve()) for entry in status.split('\0') if len(entry) > 3}
+except (OSError, subprocess.SubprocessError, ValueError):
+    GIT_TOP = None
+
+def changed_at(path: Path) -> float:
+    if GIT_TOP is not None and str(path.resolve()) not in GIT_DIRTY:
+        try:
+            out = subprocess.run(['git', '-C', str(GIT_TOP), 'log', '-1', '--format=%ct', '--', str(path.resolve())],
+                                 capture_output=True, text=True, timeout=30).stdout.strip()
+            if out:
+                return float(out)
+        except (OSError, subprocess.SubprocessError, ValueError):
+            pass
+    try:
+        return path.stat().st_mtime
+    except OSError:
+        return 0.0
+
+def resolve_source(generated: Path, source: str):
+    """Find a source path named in a header (relative to the project, the file, or any parent)."""
+    source = source.strip().strip('"')
+    if not source:
+        return None
+    candidates = [BASE_DIR / source, generated.parent / source, generated.parent / Path(source).name]
+    for parent in generated.parents:
+        candidates.append(parent / source)
+        if parent == BASE_DIR:
+            break
+    for candidate in candidates:
+        if candidate.is_file():
+            return candidate
+    matches = [p for p in BASE_DIR.rglob(Path(source).name) if p.is_file() and not should_skip(p)] if BASE_DIR.is_dir() else []
+    return matches[0] if len(matches) == 1 else None
+
+def go_camel(name: str) -> str:
+    out, upper = [], True
+    if name.startswith('_'):
+        out.append('X')
+        name = name[1:]
+    for i, ch in enumerate(name):
+        nxt = name[i + 1:i + 2]
+        if ch == '_' and nxt.islower():
+            upper = True
+            continue
+        if ch.isdigit():
+            out.append(ch)
+            upper = nxt.islower()
+            continue
+        out.append(ch.upper() if upper else ch)
+        upper = False
+    return ''.join(out)
+
+def proto_messages(text: str):
+    """Map Go message type name -> proto field names (oneof members excluded)."""
+    text = re.sub(r'//[^\n]*|/\*.*?\*/', '', text, flags=re.S)
+    messages, stack = {}, []
+    for raw in text.splitlines():
+        line = raw.strip()
+        opened = re.match(r'^(message|enum|oneof|service|extend)\s+([\w.]+)\s*\{', line)
+        if opened:
+            kind, name = opened.groups()
+            if kind == 'message':
+                parents = [n for k, n in stack if k == 'message']
+                go_name = '_'.join([go_camel(n) for n in parents] + [go_camel(name)])
+                messages[go_name] = []
+                stack.append((kind, go_name))
+            else:
+                stack.append((kind, name))
+            if line.endswith('}'):
+                stack.pop()
+            continue
+        if stack and stack[-1][0] == 'message':
+            field = re.match(r'^(?:repeated\s+|optional\s+|required\s+)?(?:map\s*<[^>]+>|[\w.]+)\s+(\w+)\s*=\s*\d+', line)
+            if field:
+                messages[stack[-1][1]].append(field.group(1))
+        for _ in range(line.count('}') - line.count('{')):
+            if stack:
+                stack.pop()
+    return messages
+
+def go_struct_fields(lines, type_name):
+    for i, text in enumerate(lines):
+        if re.match(r'^type\s+' + re.escape(type_name) + r'\s+struct\s*\{', text):
+            fields, j = set(), i + 1
+            while j < len(lines) and not lines[j].startswith('}'):
+                m = re.match(r'^\s*([A-Za-z_]\w*)\s+\S', lines[j])
+                if m:
+                    fields.add(m.group(1))
+                j += 1
+            return i, fields
+    return None, None
+
+def interface_methods(lines, name):
+    for i, text in enumerate(lines):
+        if re.match(r'^type\s+' + re.escape(name) + r'\s+interface\s*\{', text):
+            methods, j = set(), i + 1
+            while j < len(lines) and not lines[j].startswith('}'):
+                m = re.match(r'^\s*([A-Z]\w*)\s*\(', lines[j])
+                if m:
+                    methods.add(m.group(1))
+                j += 1
+            return methods
+    return None
+
+def typed_constants(files, type_name):
+    names = set()
+    for lines in files:
+        in_block, current = False, None
+        for text in lines:
+            code = text.split('//', 1)[0].strip()
+            if re.match(r'^const\s*\($', code):
+                in_block, current = True, None
+                continue
+            if in_block and code == ')':
+                in_block = False
+                continue
+            single = re.match(r'^const\s+(\w+)\s+(\w+)\s*=', code)
+            if single and single.group(2) == type_name:
+                names.add(single.group(1))
+            if not in_block or not code:
+                continue
+            typed = re.match(r'^(\w+)\s+(\w+)\s*=', code)
+            if typed:
+                current = typed.group(2)
+            elif re.match(r'^\w+\s*=', code):
+                current = None
+            elif not re.match(r'^\w+$', code):
+                continue
+            name = code.split()[0].rstrip('=')
+            if current == type_name and name != '_':
+                names.add(name)
+    return names
+
+issues = defaultdict(list)
+by_dir = defaultdict(list)
+generated = []
+for path in sorted(iter_files(ROOT)):
+    lines = read_lines(path)
+    gen, header_at, package_at = generator_header(lines)
+    if gen is None:
+        by_dir[path.parent].append(lines)
+        if path.name.endswith('.pb.go'):
+            issues['go.gen.hand-edited'].append(f'{relpath(path)}:1 (no "Code generated ... DO NOT EDIT." header)')
+        continue
+    generated.append((path, lines, gen))
+    if header_at > package_at:
+        issues['go.gen.hand-edited'].append(
+            f'{relpath(path)}:{header_at + 1} (DO NOT EDIT header below the package clause; go vet and linters treat the file as hand-written)')
+
+for path, lines, gen in generated:
+    rel = relpath(path)
+    decls = [(i, FUNC_RE.match(text)) for i, text in enumerate(lines) if text.startswith('func')]
+    if 'protoc-gen-go' in gen and not path.name.endswith('_grpc.pb.go'):
+        for i, m in decls:
+            if m and 'ubs:ignore' not in lines[i]:
+                recv, name = m.groups()
+                if (recv and not PB_METHODS.match(name)) or (not recv and not PB_FUNCS.match(name)):
+                    issues['go.gen.hand-edited'].append(f'{rel}:{i + 1} ({(recv + ".") if recv else ""}{name})')
+        proto = resolve_source(path, header_value(lines, 'source'))
+        if proto is not None:
+            try:
+                messages = proto_messages(proto.read_text(encoding='utf-8', errors='ignore'))
+            except OSError:
+                messages = {}
+            for message, fields in messages.items():
+                at, present = go_struct_fields(lines, message)
+                if present is None:
+                    issues['go.gen.stale'].append(f'{rel}:1 (message {message} from {relpath(proto)} not generated)')
+                    continue
+                missing = [f for f in fields if go_camel(f) not in present]
+                if missing:
+                    issues['go.gen.stale'].append(f'{rel}:{at + 1} ({message} lacks {", ".join(missing)} from {relpath(proto)})')
+            if changed_at(proto) > changed_at(path):
+                issues['go.gen.stale'].append(f'{rel}:1 ({relpath(proto)} changed after it was generated)')
+    elif 'MockGen' in gen:
+        types = {m.group(1) for m in map(TYPE_RE.match, lines) if m}
+        for i, m in decls:
+            if not m or 'ubs:ignore' in lines[i]:
+                continue
+            recv, name = m.groups()
+            if (recv and not recv.startswith('Mock')) or (not recv and not name.startswith('NewMock')):
+                issues['go.gen.hand-edited'].append(f'{rel}:{i + 1} ({(recv + ".") if recv else ""}{name})')
+        source = resolve_source(path, header_value(lines, 'Source').split(' (', 1)[0])
+        if source is not None and source.suffix == '.go':
+            source_lines = read_lines(source)
+            for mock in sorted(t for t in types if t.startswith('Mock') and not t.endswith('MockRecorder')):
+                methods = interface_methods(source_lines, mock[len('Mock'):])
+                if methods is None:
+                    continue
+                allowed = methods | {'EXPECT'}
+                for i, m in decls:
+                    if m and m.group(1) in (mock, mock + 'MockRecorder') and m.group(2) not in allowed \
+                            and 'ubs:ignore' not in lines[i]:
+                        issues['go.gen.hand-edited'].append(f'{rel}:{i + 1} ({m.group(1)}.{m.group(2)} is not in {mock[4:]})')
+                generated_methods = {m.group(2) for _, m in decls if m and m.group(1) == mock}
+                missing = sorted(methods - generated_methods)
+                if missing:
+                    issues['go.gen.stale'].append(f'{rel}:1 ({mock} lacks {", ".join(missing)} from {relpath(source)})')
+            if changed_at(source) > changed_at(path):
+                issues['go.gen.stale'].append(f'{rel}:1 ({relpath(source)} changed after it was generated)')
+    elif 'stringer' in gen:
+        type_names = re.findall(r'-type[= ]([\w,]+)', gen)
+        type_names = [t for group in type_names for t in group.split(',') if t]
+        for i, m in decls:
+            if m and 'ubs:ignore' not in lines[i] and not ((m.group(1) in type_names and m.group(2) == 'String') or (not m.group(1) and m.group(2) == '_')):
+                issues['go.gen.hand-edited'].append(f'{rel}:{i + 1} ({(m.group(1) + ".") if m.group(1) else ""}{m.group(2)})')
+        listed = set(re.findall(r'_\s*=\s*x\[(\w+)\s*-', '\n'.join(lines)))
+        siblings = by_dir.get(path.parent, [])
+        declared = {t: typed_constants(siblings, t) for t in type_names}
+        for type_name, names in declared.items():
+            missing = sorted(names - listed)
+            if missing:
+                issues['go.gen.stale'].append(f'{rel}:1 ({type_name} constants {", ".join(missing)} have no String() case)')
+        if declared and all(declared.values()):
+            removed = sorted(listed - set().union(*declared.values()))
+            if removed:
+                issues['go.gen.stale'].append(f'{rel}:1 (constants {", ".join(removed)} no longer exist)')
+
+checksum_file = BASE_DIR / CHECKSUM_FILE
+if checksum_file.is_file():
+    for n, text in enumerate(read_lines(checksum_file), start=1):
+        m = re.match(r'^([0-9a-fA-F]{64})\s+\*?(.+?)\s*$', text)
+        if not m:
+            continue
+        target = BASE_DIR / m.group(2)
+        try:
+            digest = hashlib.sha256(target.read_bytes()).hexdigest()
+        except OSError:
+            continue
+        if digest != m.group(1).lower():
+            issues['go.gen.checksum-drift'].append(f'{relpath(target)}:1 ({CHECKSUM_FILE} line {n})')
+
+for rule_id in ('go.gen.hand-edited', 'go.gen.stale', 'go.gen.checksum-drift'):
+    hits = issues.get(rule_id)
+    if hits:
+        print(f"{rule_id}\t{len(hits)}\t{', '.join(hits)}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "Generated files carry intact headers and match their sources"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Context value keys and accessors
+# ────────────────────────────────────────────────────────────────────────────
+run_context_value_checks() {
+  print_subheader "context.WithValue keys, values, and accessors"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable context value checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${CONTEXT_VALUE_SEVERITY[$rule_id]:-warning}")
+    local summary=${CONTEXT_VALUE_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${CONTEXT_VALUE_REMEDIATION[$rule_id]:-"Use unexported key types and typed accessors"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+BASIC_TYPES = r'(?:string|bool|byte|rune|u?int(?:8|16|32|64)?|float(?:32|64))'
+LITERAL = r'(?:"(?:\\.|[^"\\])*"|`[^`]*`|\'(?:\\.|[^\'\\])*\'|-?\d[\w.]*|iota|true|false)'
+BASIC_CONST_RE = re.compile(r'^\s*(?:const\s+|var\s+)?([A-Za-z_]\w*)\s*(?:' + BASIC_TYPES + r'\s*)?=\s*' + LITERAL + r'\s*$')
+STRUCT_RE = re.compile(r'^type\s+([A-Za-z_]\w*)\s+struct\s*\{\s*$')
+FUNC_RE = re.compile(r'^func\s*(?:\([^)]*\)\s*)?([A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\((.*)')
+WITH_VALUE_RE = re.compile(r'\bcontext\.WithValue\(')
+VALUE_CALL_RE = re.compile(r'(?:\b(?:ctx|[A-Za-z_]\w*[Cc]tx|[A-Za-z_]\w*[Cc]ontext)|\.Context\(\))\.Value\(')
+COMMA_OK_RE = re.compile(r'\b\w+\s*,\s*\w+\s*:?=\s*[\w.()]*\.Value\(.*\)\.\(')
+LOGGER_RE = re.compile(r'(?i)(?:^|\.)(?:\w*logger|log|lg|zap\w*|slog\w*|logrus\w*|zerolog\w*)$|^(?:zap\.(?:New\w*|L|S)|slog\.(?:New|Default|With)|log\.New|logrus\.(?:New|WithFields?)|zerolog\.New)\(')
+LARGE_STRUCT_FIELDS = 6
+ACCESSOR_MAX_LINES = 15
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    for i, ch in enumerate(line):
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\' and quote != '`':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+        elif ch == '/' and line[i + 1:i + 2] == '/':
+            break
+        out.append(ch)
+    return ''.join(out)
+
+def has_ignore(lines, idx):
+    return 'ubs:ignore' in lines[idx] or (idx > 0 and 'ubs:ignore' in lines[idx - 1])
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+def call_args(text, start):
+    """Split the argument list of the call whose '(' is at text[start-1]."""
+    args, depth, cur, quote = [], 0, [], ''
+    for ch in text[start:]:
+        if quote:
+            cur.append(ch)
+            if ch == quote:
+                quote = ''
+            continue
+        if ch in ('"', '`', "'"):
+            quote = ch
+        elif ch in '([{':
+            depth += 1
+        elif ch in ')]}':
+            if depth == 0:
+                args.append(''.join(cur).strip())
+                return args
+            depth -= 1
+        elif ch == ',' and depth == 0:
+            args.append(''.join(cur).strip())
+            cur = []
+            continue
+        cur.append(ch)
+    return None
+
+def functions(code):
+    idx = 0
+    while idx < len(code):
+        m = FUNC_RE.match(code[idx])
+        if not m:
+            idx += 1
+            continue
+        depth, end, opened = 0, len(code) - 1, False
+        for j in range(idx, len(code)):
+            depth += code[j].count('{') - code[j].count('}')
+            opened = opened or '{' in code[j]
+            if opened and depth <= 0:
+                end = j
+                break
+        yield m.group(1), m.group(2), idx, end
+        idx = end + 1
+
+files = {}
+for path in sorted(iter_files(ROOT)):
+    try:
+        lines = path.read_text(encoding='utf-8', errors='ignore').splitlines()
+    except OSError:
+        continue
+    files[path] = (lines, [strip_comments(raw) for raw in lines])
+
+# Untyped/basic-typed constants (per package directory) and struct field counts.
+basic_idents, struct_fields = defaultdict(set), {}
+for path, (lines, code) in files.items():
+    in_const = False
+    for idx, text in enumerate(code):
+        stripped = text.strip()
+        if re.match(r'^(?:const|var)\s*\($', stripped):
+            in_const = True
+            continue
+        if in_const and stripped == ')':
+            in_const = False
+            continue
+        if in_const or re.match(r'^(?:const|var)\s', stripped):
+            m = BASIC_CONST_RE.match(text)
+            if m:
+                basic_idents[path.parent].add(m.group(1))
+        m = STRUCT_RE.match(text)
+        if m:
+            count = 0
+            for j in range(idx + 1, len(code)):
+                field = code[j].strip()
+                if field.startswith('}'):
+                    break
+                names = re.match(r'^((?:\w+\s*,\s*)*\w+)\s+\S', field)
+                if field:
+                    count += len(names.group(1).split(',')) if names else 1
+            struct_fields[m.group(1)] = count
+large_structs = {name for name, count in struct_fields.items() if count >= LARGE_STRUCT_FIELDS}
+
+def is_basic_key(key, consts):
+    if re.fullmatch(LITERAL, key) or re.match(r'^(?:' + BASIC_TYPES + r')\(', key):
+        return True
+    if re.search(r'"\s*\+|\+\s*"', key):
+        return True
+    return key in consts
+
+def is_heavy_value(value, local_types):
+    if LOGGER_RE.search(value):
+        return True
+    m = re.match(r'^([A-Za-z_]\w*)\{', value)
+    if m and m.group(1) in large_structs:
+        return True
+    return local_types.get(value) in large_structs
+
+issues = defaultdict(list)
+for path, (lines, code) in files.items():
+    rel = relpath(path)
+    for name, params, start, end in functions(code):
+        local_types = {}
+        for pm in re.finditer(r'\b([A-Za-z_]\w*)\s+([A-Za-z_]\w*)\s*[,)]', params):
+            local_types[pm.group(1)] = pm.group(2)
+        for text in code[start + 1:end + 1]:
+            m = re.match(r'^\s*(?:var\s+([A-Za-z_]\w*)\s+([A-Za-z_]\w*)\s*$|([A-Za-z_]\w*)\s*:=\s*([A-Za-z_]\w*)\{)', text)
+            if m:
+                local_types[m.group(1) or m.group(3)] = m.group(2) or m.group(4)
+        small = (end - start) <= ACCESSOR_MAX_LINES
+        for idx in range(start, end + 1):
+            text = code[idx]
+            if has_ignore(lines, idx):
+                continue
+            for m in WITH_VALUE_RE.finditer(text):
+                args = call_args(text, m.end())
+                if not args or len(args) != 3:
+                    continue
+                if is_basic_key(args[1], basic_idents[path.parent]):
+                    issues['go.context.basic-key'].append((rel, idx + 1))
+                if is_heavy_value(args[2], local_types):
+                    issues['go.context.heavy-value'].append((rel, idx + 1))
+            if VALUE_CALL_RE.search(text):
+                checked = bool(COMMA_OK_RE.search(text))
+                if not (checked and small):
+                    issues['go.context.value-without-accessor'].append((rel, idx + 1))
+
+for rule_id in ('go.context.basic-key', 'go.context.value-without-accessor', 'go.context.heavy-value'):
+    hits = issues.get(rule_id)
+    if hits:
+        samples = ','.join(f'{name}:{line}' for name, line in hits)
+        print(f"{rule_id}\t{len(hits)}\t{samples}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "Context values use private key types and typed accessors"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Typed nil in interfaces & interface compliance
+# ────────────────────────────────────────────────────────────────────────────
+run_nil_interface_checks() {
+  print_subheader "Typed nil in interfaces & compile-time assertions"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable typed-nil checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${NIL_IFACE_SEVERITY[$rule_id]:-warning}")
+    local summary=${NIL_IFACE_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${NIL_IFACE_REMEDIATION[$rule_id]:-"Return untyped nil for interface results"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+FUNC_RE = re.compile(r'^func\s*(?:\([^)]*\)\s*)?([A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\(')
+ERROR_METHOD_RE = re.compile(r'^func\s*\(\s*\w*\s*\*?\s*([A-Za-z_]\w*)\s*\)\s*Error\(\)\s*string\b')
+PTR_DECL_RE = re.compile(r'^\s*var\s+([A-Za-z_]\w*)\s+\*[\w.]+\s*$')
+PTR_NIL_RE = re.compile(r'^\s*([A-Za-z_]\w*)\s*:=\s*\(\*[\w.]+\)\(nil\)')
+CALL_ASSIGN_RE = re.compile(r'^\s*(?:if\s+)?([A-Za-z_]\w*)\s*:=\s*(?:[\w.]+\.)?([A-Za-z_]\w*)\(')
+IFACE_DECL_RE = re.compile(r'^\s*var\s+([A-Za-z_]\w*)\s+error\b(?:\s*=\s*(.*))?$')
+IFACE_ASSIGN_RE = re.compile(r'^\s*([A-Za-z_]\w*)\s*=\s*(.*)$')
+RETURN_RE = re.compile(r'^\s*return\b(.*)$')
+DOC_IMPL_RE = re.compile(r'^//\s*([A-Za-z_]\w*)\s+(?:implements|satisfies)\s+(?:the\s+)?([A-Za-z_][\w.]*)')
+TYPE_RE = re.compile(r'^type\s+([A-Za-z_]\w*)\s')
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    for i, ch in enumerate(line):
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\' and quote != '`':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+        elif ch == '/' and line[i + 1:i + 2] == '/':
+            break
+        out.append(ch)
+    return ''.join(out)
+
+def strip_strings(text: str) -> str:
+    return re.sub(r'"(?:\\.|[^"\\])*"|`[^`]*`|\'(?:\\.|[^\'\\])*\'', '""', text)
+
+def has_ignore(lines, idx):
+    return 'ubs:ignore' in lines[idx] or (idx > 0 and 'ubs:ignore' in lines[idx - 1])
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+def results_of(sig: str) -> str:
+    """Result list of a one-line func signature (text after the parameter list)."""
+    m = FUNC_RE.match(sig)
+    depth, i = 0, m.end() - 1
+    while i < len(sig):
+        if sig[i] == '(':
+            depth += 1
+        elif sig[i] == ')':
+            depth -= 1
+            if depth == 0:
+                break
+        i += 1
+    return sig[i + 1:].split('{', 1)[0].strip()
+
+def last_result(results: str) -> str:
+    results = results.strip()
+    if results.startswith('(') and results.endswith(')'):
+        results = results[1:-1]
+    return results.rsplit(',', 1)[-1].strip().split()[-1] if results else ''
+
+def functions(code):
+    idx = 0
+    while idx < len(code):
+        m = FUNC_RE.match(code[idx])
+        if not m:
+            idx += 1
+            continue
+        depth, end, opened = 0, len(code) - 1, False
+        for j in range(idx, len(code)):
+            depth += code[j].count('{') - code[j].count('}')
+            opened = opened or '{' in code[j]
+            if opened and depth <= 0:
+                end = j
+                break
+        yield m.group(1), results_of(code[idx]), idx, end
+        idx = end + 1
+
+def guarded(code, start, idx, var):
+    """True when the typed value was nil-checked before reaching line idx."""
+    for j in range(idx - 1, start, -1):
+        if code[j].strip():
+            if re.search(r'\b' + re.escape(var) + r'\s*!=\s*nil', code[j]):
+                return True
+            break
+    return any(re.search(r'\b' + re.escape(var) + r'\s*==\s*nil', code[j]) for j in range(start + 1, idx))
+
+files = {}
+for path in sorted(iter_files(ROOT)):
+    try:
+        lines = path.read_text(encoding='utf-8', errors='ignore').splitlines()
+    except OSError:
+        continue
+    files[path] = (lines, [strip_strings(strip_comments(raw)) for raw in lines])
+
+# Pass 1: concrete error types and functions that return a pointer to one.
+error_types, ptr_funcs = set(), set()
+for lines, code in files.values():
+    for text in code:
+        m = ERROR_METHOD_RE.match(text)
+        if m:
+            error_types.add(m.group(1))
+for lines, code in files.values():
+    for name, results, start, end in functions(code):
+        res = last_result(results)
+        if res.startswith('*') and res[1:].split('.')[-1] in error_types and ',' not in results:
+            ptr_funcs.add(name)
+
+issues = defaultdict(list)
+for path, (lines, code) in files.items():
+    rel = relpath(path)
+    for name, results, start, end in functions(code):
+        returns_error = last_result(results) == 'error'
+        ptr_vars, iface_vars = set(), {}
+        for idx in range(start + 1, end + 1):
+            text = code[idx]
+            m = PTR_DECL_RE.match(text) or PTR_NIL_RE.match(text)
+            if m:
+                ptr_vars.add(m.group(1))
+                continue
+            m = CALL_ASSIGN_RE.match(text)
+            if m and m.group(2) in ptr_funcs and ',' not in text.split(':=')[0]:
+                ptr_vars.add(m.group(1))
+            m = IFACE_DECL_RE.match(text)
+            if m:
+                iface_vars[m.group(1)] = None
+                rhs = (m.group(2) or '').strip()
+            else:
+                m = IFACE_ASSIGN_RE.match(text)
+                rhs = m.group(2).strip() if m and m.group(1) in iface_vars else ''
+            if m and rhs:
+                call = re.match(r'(?:[\w.]+\.)?([A-Za-z_]\w*)\(', rhs)
+                typed = rhs in ptr_vars or (call and call.group(1) in ptr_funcs)
+                iface_vars[m.group(1)] = idx if typed else None
+            for var, assigned in iface_vars.items():
+                if assigned is not None and re.search(r'\b' + re.escape(var) + r'\s*[!=]=\s*nil\b', text):
+                    if not has_ignore(lines, idx):
+                        issues['go.nil.typed-nil-interface-compare'].append((rel, idx + 1))
+                    iface_vars[var] = None
+            if not returns_error:
+                continue
+            r = RETURN_RE.match(text)
+            if not r or not r.group(1).strip():
+                continue
+            last = r.group(1).rsplit(',', 1)[-1].strip()
+            call = re.match(r'^(?:[\w.]+\.)?([A-Za-z_]\w*)\(.*\)$', last)
+            if (last in ptr_vars and not guarded(code, start, idx, last)) or (call and call.group(1) in ptr_funcs):
+                if not has_ignore(lines, idx):
+                    issues['go.nil.typed-nil-return'].append((rel, idx + 1))
+
+# Compile-time assertions for types documented to implement an interface.
+by_dir = defaultdict(list)
+for path in files:
+    by_dir[path.parent].append(path)
+for directory, paths in by_dir.items():
+    pkg_code = '\n'.join('\n'.join(files[p][1]) for p in paths)
+    for path in paths:
+        lines, code = files[path]
+        for idx, raw in enumerate(lines):
+            m = DOC_IMPL_RE.match(raw.strip())
+            if not m:
+                continue
+            j = idx + 1
+            while j < len(lines) and lines[j].strip().startswith('//'):
+                j += 1
+            t = TYPE_RE.match(code[j]) if j < len(lines) else None
+            if not t or t.group(1) != m.group(1):
+                continue
+            iface = m.group(2).rstrip('.')
+            assertion = re.compile(r'\b_\s+' + re.escape(iface) + r'\s*=\s*[^\n]*\b' + re.escape(t.group(1)) + r'\b')
+            if not assertion.search(pkg_code) and not has_ignore(lines, j):
+                issues['go.iface.missing-assertion'].append((relpath(path), j + 1))
+
+for rule_id in ('go.nil.typed-nil-return', 'go.nil.typed-nil-interface-compare', 'go.iface.missing-assertion'):
+    hits = issues.get(rule_id)
+    if hits:
+        samples = ','.join(f'{name}:{line}' for name, line in hits)
+        print(f"{rule_id}\t{len(hits)}\t{samples}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "No typed-nil interface values or missing interface assertions detected"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Environment variable and configuration misuse
+# ────────────────────────────────────────────────────────────────────────────
+run_env_config_checks() {
+  print_subheader "Environment variable handling"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable environment variable checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${ENV_CONFIG_SEVERITY[$rule_id]:-info}")
+    local summary=${ENV_CONFIG_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${ENV_CONFIG_REMEDIATION[$rule_id]:-"Centralize environment configuration"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+ENV_ARG = r'(?:"([^"]*)"|([A-Za-z_][\w.]*))'
+GETENV_RE = re.compile(r'\bos\.Getenv\(\s*' + ENV_ARG + r'\s*\)')
+ANY_ENV_RE = re.compile(r'\bos\.(?:Getenv|LookupEnv)\(\s*' + ENV_ARG + r'\s*\)')
+ASSIGN_RE = re.compile(r'^\s*(?:var\s+)?([A-Za-z_][\w.]*)\s*(?:string\s*)?:?=\s*os\.Getenv\(\s*' + ENV_ARG + r'\s*\)\s*$')
+PARSE_CALL = r'(?:strconv\.(?:Atoi|ParseInt|ParseUint|ParseFloat|ParseBool)|time\.ParseDuration)'
+PARSE_IGNORED_RE = re.compile(r'^\s*(?:[\w.]+\s*,\s*)?_\s*:?=\s*' + PARSE_CALL + r'\((.*)')
+PARSE_ANY_RE = re.compile(PARSE_CALL + r'\(')
+FUNC_RE = re.compile(r'^func\s*(\([^)]*\))?\s*([A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\((.*)')
+HANDLER_PARAM_RE = re.compile(r'http\.ResponseWriter|\*gin\.Context|echo\.Context|\*fiber\.Ctx|\*http\.Request')
+CONFIG_FUNC_RE = re.compile(r'(?i)config|conf$|env|setting|option|flag|load|setup|bootstrap|^init$|^main$|^new')
+CONFIG_FILE_RE = re.compile(r'(?i)^(?:config|conf|env|settings|options|flags|main)\w*\.go$')
+DEFAULT_HELPER_RE = re.compile(r'\bcmp\.Or\(|(?i:\b\w*(?:default|fallback|or(?:else)?|must|require\w*)\s*\()')
+EMPTY_CHECK = r'(?:{v}\s*[!=]=\s*""|""\s*[!=]=\s*{v}\b|len\(\s*{v}\s*\)|\bswitch\s+{v}\b|strings\.TrimSpace\(\s*{v}\s*\)\s*[!=]=)'
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path) and not path.name.endswith('_test.go'):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    for i, ch in enumerate(line):
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\' and quote != '`':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+        elif ch == '/' and line[i + 1:i + 2] == '/':
+            break
+        out.append(ch)
+    return ''.join(out)
+
+def has_ignore(lines, idx):
+    return 'ubs:ignore' in lines[idx] or (idx > 0 and 'ubs:ignore' in lines[idx - 1])
 
-def iter_match_objs(blob):
-    if isinstance(blob, list):
-        for it in blob:
-            yield from iter_match_objs(it)
-    elif isinstance(blob, dict):
-        # Most ast-grep match objects include rule id + file + range.
-        if ("range" in blob and ("file" in blob or "path" in blob)
-            and (blob.get("id") or blob.get("rule_id") or blob.get("ruleId"))):
-            yield blob
-        for v in blob.values():
-            yield from iter_match_objs(v)
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
 
-raw = open(path, "r", encoding="utf-8", errors="ignore").read().strip()
-if not raw:
-    sys.exit(0)
+def env_name(m, first=1):
+    return m.group(first) if m.group(first) is not None else m.group(first + 1)
 
-root = None
-try:
-    root = json.loads(raw)
-except Exception:
-    # Some versions can stream JSON objects; fall back line-by-line.
-    for line in raw.splitlines():
-        line = line.strip()
-        if not line:
-            continue
-        try:
-            obj = json.loads(line)
-        except json.JSONDecodeError:
-            continue
-        for match in iter_match_objs(obj):
-            rid = match.get("rule_id") or match.get("id") or match.get("ruleId")
-            if not rid:
-                continue
-            rng = match.get("range") or {}
-            start = rng.get("start") or {}
-            line_no = int(start.get("row", 0)) + 1
-            file_path = match.get("file") or match.get("path") or "?"
-            entry = stats.setdefault(rid, {"count": 0, "samples": []})
-            entry["count"] += 1
-            if len(entry["samples"]) < 3:
-                entry["samples"].append(f"{file_path}:{line_no}")
-else:
-    for match in iter_match_objs(root):
-        rid = match.get("rule_id") or match.get("id") or match.get("ruleId")
-        if not rid:
+def functions(code):
+    """Yield (name, receiver, params, start, end) for top-level func bodies."""
+    idx = 0
+    while idx < len(code):
+        m = FUNC_RE.match(code[idx])
+        if not m:
+            idx += 1
             continue
-        rng = match.get("range") or {}
-        start = rng.get("start") or {}
-        line_no = int(start.get("row", 0)) + 1
-        file_path = match.get("file") or match.get("path") or "?"
-        entry = stats.setdefault(rid, {"count": 0, "samples": []})
-        entry["count"] += 1
-        if len(entry["samples"]) < 3:
-            entry["samples"].append(f"{file_path}:{line_no}")
+        depth, end, opened = 0, idx, False
+        for j in range(idx, len(code)):
+            depth += code[j].count('{') - code[j].count('}')
+            opened = opened or '{' in code[j]
+            if opened and depth <= 0:
+                end = j
+                break
+        else:
+            end = len(code) - 1
+        yield m.group(2), m.group(1) or '', m.group(3), idx, end
+        idx = end + 1
+
+def empty_checked(code, start, end, var):
+    pattern = re.compile(EMPTY_CHECK.format(v=re.escape(var)))
+    use = re.compile(r'(?<![\w.])' + re.escape(var) + r'(?![\w])')
+    for text in code[start:end + 1]:
+        if pattern.search(text):
+            return True
+        if use.search(text) and (DEFAULT_HELPER_RE.search(text) or PARSE_ANY_RE.search(text)):
+            return True
+    return False
 
-for rid, data in stats.items():
-    print(f"{rid}\t{data['count']}\t{','.join(data['samples'])}")
+issues = defaultdict(list)
+for path in sorted(iter_files(ROOT)):
+    try:
+        lines = path.read_text(encoding='utf-8', errors='ignore').splitlines()
+    except OSError:
+        continue
+    if 'os.Getenv' not in '\n'.join(lines) and 'os.LookupEnv' not in '\n'.join(lines):
+        continue
+    code = [strip_comments(raw) for raw in lines]
+    rel = relpath(path)
+    config_file = bool(CONFIG_FILE_RE.match(path.name)) or any(
+        p.lower() in ('config', 'configs', 'conf', 'settings', 'cmd') for p in path.parent.parts)
+    for name, recv, params, start, end in functions(code):
+        business = bool(recv) or bool(HANDLER_PARAM_RE.search(params))
+        if config_file or CONFIG_FUNC_RE.search(name):
+            business = False
+        env_vars = {}
+        for idx in range(start + 1, end + 1):
+            text = code[idx]
+            if has_ignore(lines, idx):
+                continue
+            if business:
+                for m in ANY_ENV_RE.finditer(text):
+                    issues['go.env.scattered-read'].append(f'{rel}:{idx + 1} ({env_name(m)})')
+            m = ASSIGN_RE.match(text)
+            if m:
+                env_vars[m.group(1)] = env_name(m, 2)
+                if not empty_checked(code, idx + 1, end, m.group(1)):
+                    issues['go.env.getenv-unchecked'].append(f'{rel}:{idx + 1} ({env_name(m, 2)})')
+                continue
+            pm = PARSE_IGNORED_RE.match(text)
+            if pm:
+                gm = GETENV_RE.search(pm.group(1))
+                if gm:
+                    issues['go.env.strconv-error-ignored'].append(f'{rel}:{idx + 1} ({env_name(gm)})')
+                    continue
+                arg = re.match(r'\s*([A-Za-z_][\w.]*)', pm.group(1))
+                if arg and arg.group(1) in env_vars:
+                    issues['go.env.strconv-error-ignored'].append(f'{rel}:{idx + 1} ({env_vars[arg.group(1)]})')
+                    continue
+            for gm in GETENV_RE.finditer(text):
+                after = text[gm.end():]
+                if re.match(r'\s*[!=]=', after) or re.search(r'[!=]=\s*$', text[:gm.start()]):
+                    continue
+                if re.match(r'^\s*(?:if|switch|case|return)\b', text) or PARSE_ANY_RE.search(text):
+                    continue
+                if DEFAULT_HELPER_RE.search(text[:gm.start()]):
+                    continue
+                if re.match(r'^\s*[A-Z]\w*\s*:\s*os\.Getenv', text):
+                    continue  # field of a config struct literal; validated where the struct is built
+                issues['go.env.getenv-unchecked'].append(f'{rel}:{idx + 1} ({env_name(gm)})')
+
+for rule_id in ('go.env.strconv-error-ignored', 'go.env.getenv-unchecked', 'go.env.scattered-read'):
+    hits = issues.get(rule_id)
+    if hits:
+        print(f"{rule_id}\t{len(hits)}\t{', '.join(hits)}")
 PY
 )
-  rm -f "$tmp_json"
-  if [[ $printed -eq 0 ]]; then print_finding "good" "All goroutines handle errors explicitly"; fi
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "Environment variables are validated and read through configuration"
+  fi
 }
 
 # ────────────────────────────────────────────────────────────────────────────
@@ -736,17 +3180,17 @@ run_taint_analysis_checks() {
     return
   fi
   local printed=0
-  while IFS=$'\t' read -r rule_id count samples; do
+  while IFS=$'\t' read -r rule_id count samples level; do
     [[ -z "$rule_id" ]] && continue
     printed=1
-    local severity=${TAINT_SEVERITY[$rule_id]:-warning}
+    local severity; severity=$(override_severity "$level" "${TAINT_SEVERITY[$rule_id]:-warning}")
     local summary=${TAINT_SUMMARY[$rule_id]:-$rule_id}
     local desc=${TAINT_REMEDIATION[$rule_id]:-"Sanitize user input before reaching this sink"}
     if [[ -n "$samples" ]]; then
       desc+=" (e.g., $samples)"
     fi
     print_finding "$severity" "$count" "$summary" "$desc"
-  done < <(python3 - "$PROJECT_DIR" <<'PY'
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
 import re, sys
 from collections import defaultdict
 from pathlib import Path
@@ -785,7 +3229,7 @@ SINKS = [
     (re.compile(r"fmt\.Fprint[fLn]?\s*\((.+)\)"), 'go.taint.xss', 'fmt.Fprintf'),
     (re.compile(r"[A-Za-z0-9_]+\.Write\s*\((.+)\)"), 'go.taint.xss', 'ResponseWriter.Write'),
     (re.compile(r"template\.(?:Must\()?[A-Za-z0-9_]+\.Execute\s*\((.+)\)"), 'go.taint.xss', 'template.Execute'),
-    (re.compile(r"\b[A-Za-z_][\w]*(?:\.[A-Za-z_][\w]*)?\.(?:Exec|ExecContext|Query|QueryContext|QueryRow|QueryRowContext|Raw|NamedQuery|NamedExec|Select|Where|Or|Not|Having|Order)\s*\((?!\))(.+)\)"), 'go.taint.sql', 'SQL query'),
+    (re.compile(r"\b(?!cmp\.Or\b)[A-Za-z_][\w]*(?:\.[A-Za-z_][\w]*)?\.(?:Exec|ExecContext|Query|QueryContext|QueryRow|QueryRowContext|Raw|NamedQuery|NamedExec|Select|Where|Or|Not|Having|Order)\s*\((?!\))(.+)\)"), 'go.taint.sql', 'SQL query'),
     (re.compile(r"\b(?:db|tx|conn|pool|repo|store|database|queries|sqlxDB)\.Get\s*\((?!\))(.+)\)"), 'go.taint.sql', 'SQL query'),
     (re.compile(r"exec\.Command(?:Context)?\s*\((.+)\)"), 'go.taint.command', 'exec.Command'),
 ]
@@ -1057,8 +3501,7 @@ def analyze_file(path: Path, issues):
                 sample = f"{rel}:{idx} {path_desc}"
                 bucket = issues[rule]
                 bucket['count'] += 1
-                if len(bucket['samples']) < 3:
-                    bucket['samples'].append(sample)
+                bucket['samples'].append(sample)
 
 issues = defaultdict(lambda: {'count': 0, 'samples': []})
 for file_path in iter_files(ROOT):
@@ -4941,7 +7384,7 @@ grep_count_scoped_i() {
 # ═══════════════════════════════════════════════════════════════════════════
 if should_skip 1; then
 print_header "1. CONCURRENCY & GOROUTINE SAFETY"
-print_category "Detects: goroutines in loops, WaitGroup imbalance, manual lock/unlock, tickers not stopped" \
+print_category "Detects: goroutines in loops, WaitGroup imbalance, manual lock/unlock, tickers not stopped, mixed atomic/plain access, copied locks, atomic check-then-act" \
   "Race-prone constructs and lifecycle mistakes cause leaks and deadlocks"
 
 print_subheader "Goroutines launched"
@@ -4990,6 +7433,7 @@ count=$([[ "$HAS_AST_GREP" -eq 1 && -f "$AST_JSON" ]] && ast_count "go.resource.
 if [ "$count" -gt 0 ]; then print_finding "warning" "$count" "Ticker created without Stop (AST)"; fi
 
 run_async_error_checks
+run_atomic_misuse_checks
 fi
 
 # ═══════════════════════════════════════════════════════════════════════════
@@ -5029,7 +7473,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if should_skip 3; then
 print_header "3. CONTEXT PROPAGATION & CANCELLATION"
-print_category "Detects: WithCancel/Timeout without cancel, Background() in handlers, ctx not first parameter (heuristic)" \
+print_category "Detects: WithCancel/Timeout without cancel, Background() in handlers, ctx not first parameter, WithValue key/accessor hygiene" \
   "Proper context usage avoids leaks and enables graceful shutdowns"
 
 print_subheader "cancel() defer placement (AST path-sensitive-ish)"
@@ -5071,6 +7515,8 @@ if [ "$bg" -gt 0 ]; then print_finding "warning" "$bg" "Use r.Context() instead
 print_subheader "context.TODO usage"
 todo=$([[ "$HAS_AST_GREP" -eq 1 && -f "$AST_JSON" ]] && ast_count "go.context-todo" || echo 0)
 if [ "$todo" -gt 0 ]; then print_finding "info" "$todo" "context.TODO() present - ensure it’s not shipping to prod"; fi
+
+run_context_value_checks
 fi
 
 # ═══════════════════════════════════════════════════════════════════════════
@@ -5149,7 +7595,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if should_skip 5; then
 print_header "5. RESOURCE LIFECYCLE & DEFER"
-print_category "Detects: defer in loops, missing Close/Stop, DB rows leaks" \
+print_category "Detects: defer in loops, missing Close/Stop, DB rows leaks, unbounded handler caches, goroutine-per-request fan-out" \
   "Go resources must be explicitly cleaned up to avoid leaks"
 
 print_subheader "defer inside loops"
@@ -5223,6 +7669,8 @@ if [ "$count" -gt 0 ]; then print_finding "warning" "$count" "time.Tick leaks; p
 print_subheader "time.NewTimer channel not drained (heuristic)"
 count=$([[ "$HAS_AST_GREP" -eq 1 && -f "$AST_JSON" ]] && ast_count "go.resource.timer-not-drained" || echo 0)
 if [ "$count" -gt 0 ]; then print_finding "info" "$count" "time.NewTimer channel never drained"; fi
+
+run_unbounded_growth_checks
 fi
 
 # ═══════════════════════════════════════════════════════════════════════════
@@ -5268,6 +7716,9 @@ if [ "$c_tmpl_exec_ignored" -gt 0 ]; then
   [[ "$VERBOSE" -eq 1 ]] && show_ast_samples "go.template.execute-error-ignored" 6 || true
 fi
 
+run_grpc_stream_checks
+run_nil_interface_checks
+
 print_subheader "Empty if err != nil blocks"
 count=$([[ "$HAS_AST_GREP" -eq 1 && -f "$AST_JSON" ]] && ast_count "go.iferr-empty" || echo 0)
 if [ "$count" -gt 0 ]; then print_finding "warning" "$count" "Empty if err != nil { } blocks"; fi
@@ -7391,6 +9842,277 @@ PY
   )
 }
 
+run_decoder_safety_checks() {
+  print_subheader "Deserialization & decoder safety"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable decoder safety checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples level; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity; severity=$(override_severity "$level" "${DECODER_SAFETY_SEVERITY[$rule_id]:-warning}")
+    local summary=${DECODER_SAFETY_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${DECODER_SAFETY_REMEDIATION[$rule_id]:-"Bound untrusted input before decoding"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" <<'PY' | apply_rule_overrides
+import re, sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+SKIP_DIRS = {'.git', 'vendor', 'node_modules', '.cache', 'bin', 'build', 'dist'}
+
+ANY_TYPE = r'(?:interface\s*\{\s*\}|any|map\[string\]\s*(?:interface\s*\{\s*\}|any)|\[\]\s*(?:interface\s*\{\s*\}|any))'
+UNTRUSTED_RE = re.compile(
+    r'\b(?:r|req|request|httpReq)\.Body\b'
+    r'|\b(?:c|ctx)\.Request(?:\(\))?\.Body\b'
+    r'|\bos\.Stdin\b'
+)
+ACCEPT_RE = re.compile(r'^\s*(?P<name>[A-Za-z_]\w*)\s*,\s*[A-Za-z_]\w*\s*:?=\s*[A-Za-z_][\w.]*\.Accept\(\)')
+LIMIT_RE = re.compile(
+    r'\bhttp\.MaxBytesReader\s*\('
+    r'|\bio\.LimitReader\s*\('
+    r'|\bio\.LimitedReader\s*\{'
+)
+ASSIGN_RE = re.compile(r'^\s*(?:var\s+)?(?P<lhs>[A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*)(?:\s+[\w.\[\]\*]+)?\s*(?::=|=)\s*(?P<rhs>.+)$')
+ANY_VAR_RE = re.compile(rf'^\s*var\s+(?P<names>[A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*)\s+{ANY_TYPE}\s*$')
+ANY_INIT_RE = re.compile(rf'^\s*(?:var\s+)?(?P<name>[A-Za-z_]\w*)\s*:?=\s*(?:{ANY_TYPE}\s*\{{\s*\}}|make\(\s*{ANY_TYPE}|new\(\s*{ANY_TYPE}\s*\))')
+TYPED_VAR_RE = re.compile(r'^\s*var\s+(?P<name>[A-Za-z_]\w*)\s+\*?(?P<type>[A-Za-z_][\w.]*)\s*$')
+TYPED_INIT_RE = re.compile(r'^\s*(?P<name>[A-Za-z_]\w*)\s*:=\s*(?:&?(?P<lit>[A-Za-z_][\w.]*)\s*\{|new\(\s*(?P<new>[A-Za-z_][\w.]*)\s*\))')
+DECODER_NEW_RE = re.compile(r'\b(?P<pkg>json|gob|xml|yaml)\.NewDecoder\s*\(\s*(?P<src>[^)]*)\)')
+DECODE_CALL_RE = re.compile(r'(?:\b(?P<recv>[A-Za-z_]\w*)|\b(?P<inline>(?:json|gob|xml|yaml)\.NewDecoder\s*\([^)]*\)))\.Decode\s*\(\s*(?P<target>[^)]*)\)')
+UNMARSHAL_RE = re.compile(r'\b(?P<pkg>json|xml|yaml)\.Unmarshal\s*\(\s*(?P<data>[^,]+),\s*(?P<target>[^)]+)\)')
+XML_RELAXED_RE = re.compile(r'\b(?P<name>[A-Za-z_]\w*)\.(?:Strict\s*=\s*false\b|Entity\s*=|CharsetReader\s*=)')
+TYPE_STRUCT_RE = re.compile(r'^\s*type\s+(?P<name>[A-Za-z_]\w*)\s+struct\s*\{')
+INLINE_ANY_TARGET_RE = re.compile(rf'^&?\s*(?:new\(\s*{ANY_TYPE}\s*\)|{ANY_TYPE}\s*\{{\s*\}})$')
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() == '.go':
+            yield root
+        return
+    for path in root.rglob('*.go'):
+        if path.is_file() and not should_skip(path):
+            yield path
+
+def strip_comments(line: str) -> str:
+    out, quote, escape = [], '', False
+    i = 0
+    while i < len(line):
+        ch = line[i]
+        if quote:
+            out.append(ch)
+            if escape:
+                escape = False
+            elif ch == '\\':
+                escape = True
+            elif ch == quote:
+                quote = ''
+            i += 1
+            continue
+        if ch in ('"', "'", '`'):
+            quote = ch
+            out.append(ch)
+            i += 1
+            continue
+        if ch == '/' and i + 1 < len(line) and line[i + 1] == '/':
+            break
+        out.append(ch)
+        i += 1
+    return ''.join(out)
+
+def brace_delta(raw: str) -> int:
+    stripped = strip_comments(raw)
+    return stripped.count('{') - stripped.count('}')
+
+def function_ranges(lines):
+    ranges = []
+    start = None
+    depth = 0
+    for idx, raw in enumerate(lines, start=1):
+        stripped = strip_comments(raw)
+        if start is None:
+            if not re.match(r'^\s*func\b', stripped):
+                continue
+            start = idx
+            depth = brace_delta(raw)
+            if depth <= 0:
+                ranges.append((start, idx))
+                start = None
+                depth = 0
+            continue
+        depth += brace_delta(raw)
+        if depth <= 0:
+            ranges.append((start, idx))
+            start = None
+            depth = 0
+    if start is not None:
+        ranges.append((start, len(lines)))
+    return ranges
+
+def recursive_types(lines):
+    found = set()
+    idx = 0
+    while idx < len(lines):
+        match = TYPE_STRUCT_RE.match(strip_comments(lines[idx]))
+        if not match:
+            idx += 1
+            continue
+        name = match.group('name')
+        depth = brace_delta(lines[idx])
+        body = []
+        idx += 1
+        while idx < len(lines) and depth > 0:
+            body.append(strip_comments(lines[idx]))
+            depth += brace_delta(lines[idx])
+            idx += 1
+        field_re = re.compile(rf'^\s*\w+\s+(?:\*|\[\]\*?|map\[[^\]]+\]\*?)*{re.escape(name)}\b')
+        if any(field_re.match(field) for field in body):
+            found.add(name)
+    return found
+
+def has_ignore(lines, line_no):
+    idx = line_no - 1
+    return (
+        0 <= idx < len(lines) and 'ubs:ignore' in lines[idx]
+    ) or (
+        0 <= idx - 1 < len(lines) and 'ubs:ignore' in lines[idx - 1]
+    )
+
+def mentions(expr, names):
+    return any(re.search(rf'(?<![\w.]){re.escape(name)}\b', expr) for name in names)
+
+def target_name(target):
+    target = target.strip()
+    if target.startswith('&'):
+        target = target[1:].strip()
+    return target if re.fullmatch(r'[A-Za-z_]\w*', target) else ''
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+def analyze(path: Path, issues):
+    try:
+        text = path.read_text(encoding='utf-8', errors='ignore')
+    except OSError:
+        return
+    if not re.search(r'\b(?:json|gob|xml|yaml)\.(?:NewDecoder|Unmarshal)\b', text):
+        return
+    lines = text.splitlines()
+    recursive = recursive_types(lines)
+    for start, end in function_ranges(lines):
+        body = [strip_comments(raw) for raw in lines[start - 1:end]]
+        limited = any(LIMIT_RE.search(line) for line in body)
+        relaxed_xml = {m.group('name') for line in body for m in XML_RELAXED_RE.finditer(line)}
+        relaxed_xml_global = any(re.search(r'\bxml\.HTMLEntity\b', line) for line in body)
+        tainted = set()
+        any_vars = set()
+        var_types = {}
+        decoders = {}
+        for offset, line in enumerate(body):
+            line_no = start + offset
+            if not line.strip():
+                continue
+            accept = ACCEPT_RE.match(line)
+            if accept:
+                tainted.add(accept.group('name'))
+            any_var = ANY_VAR_RE.match(line)
+            if any_var:
+                any_vars.update(n.strip() for n in any_var.group('names').split(','))
+            any_init = ANY_INIT_RE.match(line)
+            if any_init:
+                any_vars.add(any_init.group('name'))
+            typed = TYPED_VAR_RE.match(line)
+            if typed:
+                var_types[typed.group('name')] = typed.group('type')
+            typed_init = TYPED_INIT_RE.match(line)
+            if typed_init:
+                var_types[typed_init.group('name')] = typed_init.group('lit') or typed_init.group('new')
+            new_dec = DECODER_NEW_RE.search(line)
+            assign = ASSIGN_RE.match(line)
+            if assign:
+                names = [n.strip() for n in assign.group('lhs').split(',') if n.strip() not in ('', '_', 'err')]
+                rhs = assign.group('rhs')
+                if new_dec and len(names) == 1:
+                    src = new_dec.group('src')
+                    decoders[names[0]] = (new_dec.group('pkg'), bool(UNTRUSTED_RE.search(src) or mentions(src, tainted)), line_no)
+                elif UNTRUSTED_RE.search(rhs) or mentions(rhs, tainted):
+                    tainted.update(names)
+            if has_ignore(lines, line_no):
+                continue
+
+            def is_any(target):
+                name = target_name(target)
+                return bool(INLINE_ANY_TARGET_RE.match(target.strip())) or (name and name in any_vars)
+
+            def is_recursive(target):
+                name = target_name(target)
+                return bool(name) and var_types.get(name, '').split('.')[-1] in recursive
+
+            for call in DECODE_CALL_RE.finditer(line):
+                target = call.group('target')
+                if call.group('inline'):
+                    inline = DECODER_NEW_RE.search(call.group('inline'))
+                    src = inline.group('src')
+                    pkg, untrusted, decl_line = inline.group('pkg'), bool(UNTRUSTED_RE.search(src) or mentions(src, tainted)), line_no
+                    recv = ''
+                elif call.group('recv') in decoders:
+                    recv = call.group('recv')
+                    pkg, untrusted, decl_line = decoders[recv]
+                else:
+                    continue
+                if not untrusted:
+                    continue
+                rule = None
+                if pkg in ('json', 'gob') and is_any(target) and not limited:
+                    rule = f'go.sec.{pkg}-untrusted-any'
+                elif pkg == 'xml' and (not limited or recv in relaxed_xml or relaxed_xml_global):
+                    rule = 'go.sec.xml-untrusted'
+                elif pkg == 'yaml' and (not limited or is_any(target) or is_recursive(target)):
+                    rule = 'go.sec.yaml-untrusted'
+                if rule:
+                    issues[rule].append((relpath(path), line_no))
+
+            for call in UNMARSHAL_RE.finditer(line):
+                data, target, pkg = call.group('data'), call.group('target'), call.group('pkg')
+                if not (UNTRUSTED_RE.search(data) or mentions(data, tainted)):
+                    continue
+                rule = None
+                if pkg == 'json' and is_any(target) and not limited:
+                    rule = 'go.sec.json-untrusted-any'
+                elif pkg == 'xml' and not limited:
+                    rule = 'go.sec.xml-untrusted'
+                elif pkg == 'yaml' and (not limited or is_any(target) or is_recursive(target)):
+                    rule = 'go.sec.yaml-untrusted'
+                if rule:
+                    issues[rule].append((relpath(path), line_no))
+
+issues = defaultdict(list)
+for file_path in iter_files(ROOT):
+    analyze(file_path, issues)
+
+for rule_id, hits in issues.items():
+    samples = ','.join(f'{name}:{line}' for name, line in hits)
+    print(f"{rule_id}\t{len(hits)}\t{samples}")
+PY
+)
+  if [[ $printed -eq 0 ]]; then
+    print_finding "good" "No untrusted input reaches unbounded or permissive decoders"
+  fi
+}
+
 count_indirect_tls_insecure_skip() {
   if ! command -v python3 >/dev/null 2>&1; then
     printf '0\n'
@@ -7470,7 +10192,7 @@ PY
 # ═══════════════════════════════════════════════════════════════════════════
 if should_skip 9; then
 print_header "9. CRYPTOGRAPHY & SECURITY"
-print_category "Detects: weak hashes, security-sensitive non-crypto randomness, timing-unsafe secret comparisons, JWT verification bypasses, InsecureSkipVerify, auth cookie flags, credentialed CORS, shell exec, dynamic SQL strings, request-controlled regex patterns, request path traversal, response header injection, open redirects, host header poisoning, outbound URL SSRF, reverse proxy SSRF, unsafe archive extraction" \
+print_category "Detects: weak hashes, security-sensitive non-crypto randomness, timing-unsafe secret comparisons, JWT verification bypasses, InsecureSkipVerify, auth cookie flags, credentialed CORS, shell exec, dynamic SQL strings, request-controlled regex patterns, request path traversal, response header injection, open redirects, host header poisoning, outbound URL SSRF, reverse proxy SSRF, unsafe archive extraction, unbounded/permissive decoders" \
   "Security footguns are easy to miss and costly to fix"
 
 print_subheader "Weak hashes (md5/sha1) and RC4"
@@ -7528,6 +10250,7 @@ run_host_header_url_checks
 run_reverse_proxy_ssrf_checks
 run_outbound_url_checks
 run_archive_extraction_checks
+run_decoder_safety_checks
 run_taint_analysis_checks
 fi
 
@@ -7680,7 +10403,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if should_skip 16; then
 print_header "16. PANIC/RECOVER & TIME PATTERNS (AST Pack)"
-print_category "AST-detected: panic(), recover outside defer, time.Tick, time.After in loop" \
+print_category "AST-detected: panic(), recover outside defer, time.Tick, time.After in loop; wall-clock elapsed math, zone-less time.Parse, test sleeps, time.Time ==" \
   "Codifies common pitfalls as precise AST rules"
 
 if [[ "$HAS_AST_GREP" -eq 1 && -f "$AST_JSON" ]]; then
@@ -7728,6 +10451,8 @@ else
     say "${YELLOW}${WARN} ast-grep not available; AST categories summarized via regex only.${RESET}"
   fi
 fi
+
+run_time_correctness_checks
 fi
 
 # ═══════════════════════════════════════════════════════════════════════════
@@ -7896,6 +10621,50 @@ if [ "$srv_new" -gt 0 ] && [ "$srv_sd" -eq 0 ]; then
 fi
 fi
 
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 23: NUMERIC & FLOATING-POINT
+# ═══════════════════════════════════════════════════════════════════════════
+if should_skip 23; then
+print_header "23. NUMERIC & FLOATING-POINT"
+print_category "Detects: float ==/!= comparisons, float money accumulation in loops, float-to-int cent truncation" \
+  "Binary floating point cannot represent most decimal amounts; money math needs integers or decimals."
+
+run_numeric_money_checks
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 24: CONFIGURATION & ENVIRONMENT
+# ═══════════════════════════════════════════════════════════════════════════
+if should_skip 24; then
+print_header "24. CONFIGURATION & ENVIRONMENT"
+print_category "Detects: os.Getenv without empty checks, strconv.Atoi(os.Getenv(...)) with ignored errors, env reads in handlers/methods" \
+  "Medium-confidence heuristics: an unset variable is just \"\", so it surfaces far from startup as a bad DSN, port, or zero limit."
+
+run_env_config_checks
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 25: INIT & PACKAGE-LEVEL STATE
+# ═══════════════════════════════════════════════════════════════════════════
+if should_skip 25; then
+print_header "25. INIT & PACKAGE-LEVEL STATE"
+print_category "Detects: network/file/exec work in init(), package-level maps/slices mutated without a lock, flag.Parse() in init or library code" \
+  "init() runs on every import with no way to return an error, and package globals are shared by every goroutine."
+
+run_package_state_checks
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 26: GENERATED CODE PROVENANCE
+# ═══════════════════════════════════════════════════════════════════════════
+if should_skip 26; then
+print_header "26. GENERATED CODE PROVENANCE"
+print_category "Detects: hand edits to protoc-gen-go/mockgen/stringer output, missing or misplaced DO NOT EDIT headers, output stale against its .proto/interface/const source, drift from .ubs-generated.sha256" \
+  "Hand edits vanish on the next go generate, and stale output ships an API that no longer matches its source."
+
+run_generated_code_checks
+fi
+
 # restore pipefail if we relaxed it
 end_scan_section
 
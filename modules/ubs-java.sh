@@ -329,6 +329,32 @@ declare -A RESOURCE_LIFECYCLE_REGEX_REMEDIATION=(
   [jdbc_close]='Use try-with-resources or explicitly close connections'
 )
 
+# JVM (Java/Kotlin) hygiene metadata: resource scoping, swallowed exceptions, string-built SQL, shell exec
+JVM_HYGIENE_RULE_IDS=(
+  java.resource.closeable-no-twr
+  java.exception.broad-catch-swallowed
+  java.sql.string-built
+  java.exec.shell-string
+)
+declare -A JVM_HYGIENE_SUMMARY=(
+  [java.resource.closeable-no-twr]='Closeable opened outside try-with-resources / use {}'
+  [java.exception.broad-catch-swallowed]='Broad catch (Exception/Throwable) swallows the error'
+  [java.sql.string-built]='SQL built by string concatenation/interpolation'
+  [java.exec.shell-string]='Runtime.exec with a shell command string'
+)
+declare -A JVM_HYGIENE_REMEDIATION=(
+  [java.resource.closeable-no-twr]='Open streams/readers/connections in try-with-resources (Java) or wrap them in .use { } (Kotlin) so they close on every path'
+  [java.exception.broad-catch-swallowed]='Catch the specific exception type, and rethrow, wrap, or log it with the cause instead of silently continuing'
+  [java.sql.string-built]='Use PreparedStatement placeholders (?) or named parameters instead of splicing values into the SQL text'
+  [java.exec.shell-string]='Pass an argv array/list to ProcessBuilder without sh -c; Runtime.exec(String) tokenizes on spaces and shell wrappers enable injection'
+)
+declare -A JVM_HYGIENE_SEVERITY=(
+  [java.resource.closeable-no-twr]='warning'
+  [java.exception.broad-catch-swallowed]='warning'
+  [java.sql.string-built]='warning'
+  [java.exec.shell-string]='critical'
+)
+
 # ────────────────────────────────────────────────────────────────────────────
 # Search engine configuration (rg if available, else grep)
 # ────────────────────────────────────────────────────────────────────────────
@@ -659,6 +685,365 @@ print(",".join(samples))
 PY
 }
 
+run_jvm_hygiene_checks() {
+  local title="$1" good="$2"
+  shift 2
+  print_subheader "$title"
+  if ! command -v python3 >/dev/null 2>&1; then
+    print_finding "info" 0 "python3 not available" "Install python3 to enable JVM hygiene checks"
+    return
+  fi
+  local printed=0
+  while IFS=$'\t' read -r rule_id count samples; do
+    [[ -z "$rule_id" ]] && continue
+    printed=1
+    local severity=${JVM_HYGIENE_SEVERITY[$rule_id]:-warning}
+    local summary=${JVM_HYGIENE_SUMMARY[$rule_id]:-$rule_id}
+    local desc=${JVM_HYGIENE_REMEDIATION[$rule_id]:-"Review JVM hygiene finding"}
+    if [[ -n "$samples" ]]; then
+      desc+=" (e.g., $samples)"
+    fi
+    print_finding "$severity" "$count" "$summary" "$desc"
+  done < <(python3 - "$PROJECT_DIR" "$@" <<'PY'
+import re
+import sys
+from collections import defaultdict
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+WANTED = set(sys.argv[2:])
+SKIP_DIRS = {'.git', '.gradle', '.mvn', 'build', 'target', 'out', 'node_modules', '.cache'}
+EXTS = {'.java', '.kt', '.kts'}
+
+CLOSEABLE_TYPES = (
+    'FileInputStream', 'FileOutputStream', 'FileReader', 'FileWriter', 'RandomAccessFile',
+    'ZipFile', 'JarFile', 'Socket', 'ServerSocket', 'Scanner', 'PrintWriter',
+    'BufferedReader', 'BufferedWriter', 'InputStreamReader', 'OutputStreamWriter',
+    'ObjectInputStream', 'ObjectOutputStream',
+)
+CTOR_RE_JAVA = re.compile(r'\bnew\s+(?:[a-z][\w]*\.)*(?:' + '|'.join(CLOSEABLE_TYPES) + r')\s*\(')
+CTOR_RE_KOTLIN = re.compile(r'(?<![\w.])(?:[a-z][\w]*\.)*(?:' + '|'.join(CLOSEABLE_TYPES) + r')\s*\(')
+FACTORY_RE = re.compile(
+    r'\b(?:\w*DriverManager|\w*[dD]ataSource)\s*\.\s*getConnection\s*\('
+    r'|\bFiles\s*\.\s*(?:newInputStream|newOutputStream|newBufferedReader|newBufferedWriter|lines|list|walk|newDirectoryStream)\s*\('
+)
+KOTLIN_OPENER_RE = re.compile(r'\.\s*(?:bufferedReader|bufferedWriter|inputStream|outputStream|reader|writer|printWriter)\s*\(')
+STDIO_ARG_RE = re.compile(r'System\s*\.\s*(?:in|out|err)\b')
+JAVA_DECL_RE = re.compile(r'(?:^|[;{}(])\s*(?:final\s+)?(?:var|[A-Z][\w.]*(?:\s*<[^;=]*>)?(?:\[\])?)\s+(?P<name>\w+)\s*=\s*$')
+KOTLIN_DECL_RE = re.compile(r'\b(?:val|var)\s+(?P<name>\w+)(?:\s*:\s*[^=]+)?\s*=\s*$')
+RECEIVER_TAIL_RE = re.compile(r'[\w.]*(?:\([^()]*\))?(?:\s*\.\s*\w+(?:\([^()]*\))?)*\s*$')
+
+BROAD_TYPES = {'Exception', 'Throwable', 'RuntimeException'}
+JAVA_CATCH_RE = re.compile(r'\bcatch\s*\(\s*(?:final\s+)?(?P<types>[\w.]+(?:\s*\|\s*[\w.]+)*)\s+(?P<var>\w+)\s*\)\s*\{')
+KOTLIN_CATCH_RE = re.compile(r'\bcatch\s*\(\s*(?P<var>\w+)\s*:\s*(?P<types>[\w.]+)\s*\)\s*\{')
+INTENTIONAL_NAMES = {'ignored', 'ignore', 'expected', '_'}
+
+SQL_TEXT_RE = re.compile(
+    r'(?is)\b(?:select\b.+\bfrom|insert\s+into|update\s+\w+.*\bset|delete\s+from|merge\s+into)\b|\bwhere\s+\w+\s*(?:=|<|>|like|in)\s*$'
+)
+FORMAT_CALL_RE = re.compile(r'\bString\s*\.\s*format\s*\(\s*$|\.\s*formatted\s*\(')
+KOTLIN_TEMPLATE_RE = re.compile(r'\$(?:\{|[A-Za-z_])')
+
+EXEC_RE = re.compile(r'\b(?:Runtime\s*\.\s*getRuntime\s*\(\s*\)|\w*[rR]untime)\s*\.\s*exec\s*\(')
+SHELL_WRAPPER_RE = re.compile(
+    r'^\s*(?:new\s+String\s*\[\s*\]\s*\{|arrayOf\s*\(|listOf\s*\()\s*"(?:/bin/|/usr/bin/)?(?:sh|bash|zsh|cmd(?:\.exe)?|powershell|pwsh)"\s*,\s*"(?:-c|/c|/C|-Command)"'
+)
+
+SHELL_PREFIX_RE = re.compile(r'\s*(?:/bin/|/usr/bin/)?(?:sh|bash|zsh)\s+-c\b|\s*cmd(?:\.exe)?\s+/[cC]\b')
+
+
+def should_skip(path: Path) -> bool:
+    return any(part in SKIP_DIRS for part in path.parts)
+
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() in EXTS:
+            yield root
+        return
+    for path in root.rglob('*'):
+        if path.is_file() and path.suffix.lower() in EXTS and not should_skip(path):
+            yield path
+
+
+def relpath(path: Path) -> str:
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+
+def mask(text: str):
+    """Blank comments and string bodies (keeping offsets); return masked text and string spans."""
+    chars = list(text)
+    strings = []
+    i, n = 0, len(text)
+
+    def blank(start, end):
+        for pos in range(start, min(end, n)):
+            if chars[pos] != '\n':
+                chars[pos] = ' '
+
+    while i < n:
+        if text.startswith('//', i):
+            end = text.find('\n', i)
+            end = n if end < 0 else end
+            blank(i, end)
+            i = end
+        elif text.startswith('/*', i):
+            end = text.find('*/', i + 2)
+            end = n if end < 0 else end + 2
+            blank(i, end)
+            i = end
+        elif text.startswith('"""', i):
+            end = text.find('"""', i + 3)
+            end = n if end < 0 else end + 3
+            strings.append((i, end, text[i + 3:end - 3]))
+            blank(i + 1, end - 1)
+            i = end
+        elif text[i] == '"':
+            j = i + 1
+            while j < n and text[j] != '"' and text[j] != '\n':
+                j += 2 if text[j] == '\\' else 1
+            end = min(j + 1, n)
+            strings.append((i, end, text[i + 1:end - 1]))
+            blank(i + 1, end - 1)
+            i = end
+        elif text[i] == "'":
+            j = i + 1
+            while j < n and text[j] != "'" and text[j] != '\n':
+                j += 2 if text[j] == '\\' else 1
+            blank(i + 1, j)
+            i = j + 1
+        else:
+            i += 1
+    return ''.join(chars), strings
+
+
+def match_close(masked: str, open_idx: int, open_ch: str, close_ch: str) -> int:
+    depth = 0
+    for idx in range(open_idx, len(masked)):
+        ch = masked[idx]
+        if ch == open_ch:
+            depth += 1
+        elif ch == close_ch:
+            depth -= 1
+            if depth == 0:
+                return idx
+    return -1
+
+
+def enclosing_paren(masked: str, offset: int) -> int:
+    depth = 0
+    for idx in range(offset - 1, -1, -1):
+        ch = masked[idx]
+        if ch in ')]':
+            depth += 1
+        elif ch in '([':
+            if depth == 0:
+                return idx if ch == '(' else -1
+            depth -= 1
+        elif ch in ';{}' and depth == 0:
+            return -1
+    return -1
+
+
+def statement_prefix(masked: str, offset: int, kotlin: bool) -> str:
+    start = max(masked.rfind(';', 0, offset), masked.rfind('{', 0, offset), masked.rfind('}', 0, offset)) + 1
+    if kotlin:
+        start = max(start, masked.rfind('\n', 0, offset) + 1)
+    return masked[start:offset]
+
+
+def line_of(text: str, offset: int) -> int:
+    return text.count('\n', 0, offset) + 1
+
+
+def ignored(lines, line_no: int) -> bool:
+    here = lines[line_no - 1] if 0 < line_no <= len(lines) else ''
+    prev = lines[line_no - 2] if 1 < line_no <= len(lines) + 1 else ''
+    return 'ubs:ignore' in here or 'ubs:ignore' in prev
+
+
+def acquisitions(masked: str, kotlin: bool):
+    found = []
+    ctor_re = CTOR_RE_KOTLIN if kotlin else CTOR_RE_JAVA
+    for m in ctor_re.finditer(masked):
+        found.append((m.start(), m.end() - 1))
+    for m in FACTORY_RE.finditer(masked):
+        found.append((m.start(), m.end() - 1))
+    if kotlin:
+        for m in KOTLIN_OPENER_RE.finditer(masked):
+            found.append((m.start(), m.end() - 1))
+    return sorted(set(found))
+
+
+def resource_released(masked: str, name: str, after: int) -> bool:
+    tail = masked[after:]
+    esc = re.escape(name)
+    return bool(re.search(
+        rf'\b{esc}\s*(?:\?\.|\.)\s*(?:close|use|useLines)\b'
+        rf'|\btry\s*\(\s*(?:[\w.<>]+\s+\w+\s*=\s*)?{esc}\s*[;)]'
+        rf'|\breturn\s+{esc}\s*(?:;|$)|\bcloseQuietly\s*\(\s*{esc}\b',
+        tail,
+        re.MULTILINE,
+    ))
+
+
+def scan_resources(text, masked, kotlin, path, hits):
+    for start, open_idx in acquisitions(masked, kotlin):
+        close_idx = match_close(masked, open_idx, '(', ')')
+        if close_idx < 0:
+            continue
+        if STDIO_ARG_RE.search(text[open_idx:close_idx]):
+            continue
+        after = masked[close_idx + 1:close_idx + 40].lstrip()
+        if re.match(r'\.\s*use(?:Lines)?\b', after) or re.match(r'\?\.\s*use\b', after):
+            continue
+        chained = after.startswith('.') or after.startswith('?.')
+        if not chained:
+            paren = enclosing_paren(masked, start)
+            if paren >= 0:
+                before = masked[:paren].rstrip()
+                if re.search(r'\btry$', before):
+                    continue
+                # Wrapped by another closeable (new BufferedReader(new FileReader(f))): the outer call decides.
+                continue
+            prefix = statement_prefix(masked, start, kotlin)
+            if masked[start] == '.':
+                # Kotlin opener (File(p).bufferedReader()): drop the receiver expression.
+                prefix = RECEIVER_TAIL_RE.sub('', prefix)
+            if re.search(r'\breturn\s*$', prefix):
+                continue
+            if prefix.strip():
+                decl = (KOTLIN_DECL_RE if kotlin else JAVA_DECL_RE).search(prefix)
+                if not decl:
+                    # Field assignment or other expression: the owning object manages the lifecycle.
+                    continue
+                if resource_released(masked, decl.group('name'), close_idx):
+                    continue
+        hits['java.resource.closeable-no-twr'].append((path, line_of(text, start)))
+
+
+def scan_catches(text, masked, kotlin, path, hits):
+    catch_re = KOTLIN_CATCH_RE if kotlin else JAVA_CATCH_RE
+    for m in catch_re.finditer(masked):
+        types = {t.strip().rsplit('.', 1)[-1] for t in m.group('types').split('|')}
+        if not types & BROAD_TYPES:
+            continue
+        var = m.group('var')
+        if var in INTENTIONAL_NAMES:
+            continue
+        open_idx = m.end() - 1
+        close_idx = match_close(masked, open_idx, '{', '}')
+        if close_idx < 0:
+            continue
+        body = masked[open_idx + 1:close_idx]
+        if re.search(r'\bthrow\b', body):
+            continue
+        body = re.sub(rf'\b{re.escape(var)}\s*\.\s*printStackTrace\s*\(\s*\)', '', body)
+        if re.search(rf'\b{re.escape(var)}\b', body):
+            continue
+        hits['java.exception.broad-catch-swallowed'].append((path, line_of(text, m.start())))
+
+
+def scan_sql(text, masked, strings, kotlin, path, hits):
+    for start, end, content in strings:
+        if not SQL_TEXT_RE.search(content):
+            continue
+        built = False
+        if kotlin and KOTLIN_TEMPLATE_RE.search(content):
+            built = True
+        right = masked[end:end + 80].lstrip()
+        if right.startswith('+'):
+            operand = right[1:].lstrip()
+            if operand and not operand.startswith('"'):
+                built = True
+        left = masked[max(0, start - 80):start].rstrip()
+        if left.endswith('+'):
+            operand = left[:-1].rstrip()
+            if operand and not operand.endswith('"'):
+                built = True
+        if FORMAT_CALL_RE.search(masked[max(0, start - 40):start]) and re.search(r'%[sd]', content):
+            built = True
+        if re.match(r'\.\s*formatted\s*\(', right) and re.search(r'%[sd]', content):
+            built = True
+        if built:
+            hits['java.sql.string-built'].append((path, line_of(text, start)))
+
+
+def scan_exec(text, masked, strings, kotlin, path, hits):
+    built_vars = set()
+    for m in re.finditer(r'\b(?:String|val|var)\s+(\w+)(?:\s*:\s*String)?\s*=\s*([^;\n]+)', masked):
+        rhs_start = m.start(2)
+        rhs_end = m.end(2)
+        rhs = masked[rhs_start:rhs_end]
+        literal_inside = [s for s in strings if rhs_start <= s[0] < rhs_end]
+        if '+' in rhs or re.search(r'String\s*\.\s*format|\.formatted', rhs) or (
+            kotlin and any(KOTLIN_TEMPLATE_RE.search(s[2]) for s in literal_inside)
+        ):
+            built_vars.add(m.group(1))
+    for m in EXEC_RE.finditer(masked):
+        open_idx = m.end() - 1
+        close_idx = match_close(masked, open_idx, '(', ')')
+        if close_idx < 0:
+            continue
+        arg = text[open_idx + 1:close_idx]
+        arg_masked = masked[open_idx + 1:close_idx].strip()
+        first = re.split(r',(?![^(]*\))', arg_masked, maxsplit=1)[0].strip()
+        shell = False
+        if SHELL_WRAPPER_RE.search(arg):
+            shell = True
+        elif first.startswith('"'):
+            literals = [s for s in strings if open_idx < s[0] < close_idx]
+            if '+' in first or (kotlin and any(KOTLIN_TEMPLATE_RE.search(s[2]) for s in literals)):
+                shell = True
+            elif literals and (SHELL_PREFIX_RE.match(literals[0][2]) or re.search(r'[|;&<>`]|\$\(', literals[0][2])):
+                shell = True
+        elif re.match(r'String\s*\.\s*format\s*\(', first) or '.formatted(' in first.replace(' ', ''):
+            shell = True
+        elif re.fullmatch(r'\w+', first) and first in built_vars:
+            shell = True
+        if shell:
+            hits['java.exec.shell-string'].append((path, line_of(text, m.start())))
+
+
+hits = defaultdict(list)
+for path in iter_files(ROOT):
+    try:
+        text = path.read_text(encoding='utf-8', errors='ignore')
+    except OSError:
+        continue
+    kotlin = path.suffix.lower() in {'.kt', '.kts'}
+    masked, strings = mask(text)
+    lines = text.splitlines()
+    rel = relpath(path)
+    local = defaultdict(list)
+    if 'java.resource.closeable-no-twr' in WANTED:
+        scan_resources(text, masked, kotlin, rel, local)
+    if 'java.exception.broad-catch-swallowed' in WANTED:
+        scan_catches(text, masked, kotlin, rel, local)
+    if 'java.sql.string-built' in WANTED:
+        scan_sql(text, masked, strings, kotlin, rel, local)
+    if 'java.exec.shell-string' in WANTED:
+        scan_exec(text, masked, strings, kotlin, rel, local)
+    for rule_id, entries in local.items():
+        for file_name, line_no in sorted(set(entries), key=lambda e: e[1]):
+            if not ignored(lines, line_no):
+                hits[rule_id].append(f'{file_name}:{line_no}')
+
+for rule_id in sys.argv[2:]:
+    entries = hits.get(rule_id)
+    if entries:
+        print(f"{rule_id}\t{len(entries)}\t{', '.join(entries[:3])}")
+PY
+)
+  if [[ "$printed" -eq 0 ]]; then
+    print_finding "good" "$good"
+  fi
+}
+
 run_archive_extraction_checks() {
   print_subheader "Archive extraction path traversal"
   if ! command -v python3 >/dev/null 2>&1; then
@@ -3516,7 +3901,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if should_run 4; then
 print_header "4. SECURITY"
-print_category "Detects: Insecure SSL, weak hashes, http://, insecure deserialization, shell command execution, security-sensitive non-crypto randomness, request path traversal, response header injection, open redirects, outbound URL SSRF, unsafe archive extraction" \
+print_category "Detects: Insecure SSL, weak hashes, http://, insecure deserialization, shell command execution (incl. Runtime.exec shell strings), security-sensitive non-crypto randomness, request path traversal, response header injection, open redirects, outbound URL SSRF, unsafe archive extraction" \
   "Security misconfigurations expose users to attacks and data breaches"
 
 print_subheader "SSL verification disabled (CRITICAL)"
@@ -3563,6 +3948,8 @@ if [ "$pb_shell" -gt 0 ]; then
   show_detailed_finding "$pb_shell_pattern" 3
 fi
 
+run_jvm_hygiene_checks "Runtime.exec with shell command strings" "Runtime.exec calls pass argv arrays without shell wrappers" java.exec.shell-string
+
 run_path_traversal_checks
 run_response_header_injection_checks
 run_open_redirect_checks
@@ -3575,7 +3962,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if should_run 5; then
 print_header "5. I/O & RESOURCES"
-print_category "Detects: missing charset, blocking reads in loops, delete() unchecked" \
+print_category "Detects: missing charset, blocking reads in loops, Closeables outside try-with-resources / use {}" \
   "I/O patterns that cause correctness or performance issues"
 
 print_subheader "InputStreamReader without charset"
@@ -3591,13 +3978,17 @@ read_all_bytes_loop=$("${GREP_RN[@]}" -e "for[[:space:]]*\(|while[[:space:]]*\("
 read_all_bytes_loop=${read_all_bytes_loop:-0}
 if [ "$read_all_bytes_loop" -gt 0 ]; then print_finding "warning" "$read_all_bytes_loop" "Files.readAllBytes in loop - consider streaming"; fi
 
-print_subheader "Try-with-resources coverage"
-twr_candidates=$("${GREP_RN[@]}" -e "new[[:space:]]+(File(Input|Output)Stream|Buffered(Reader|Writer)|Scanner|FileReader|FileWriter|Connection|PreparedStatement)\(" "$PROJECT_DIR" 2>/dev/null | grep -vE "try[[:space:]]*\\(" | count_lines || true)
-if [ "$twr_candidates" -gt 0 ]; then
-  print_finding "warning" "$twr_candidates" "Closeable created outside try-with-resources" "Wrap AutoCloseable objects in try-with-resources or close them in finally blocks"
-  show_detailed_finding "new[[:space:]]+(File(Input|Output)Stream|Buffered(Reader|Writer)|Scanner|Connection|PreparedStatement)\(" 3
+if command -v python3 >/dev/null 2>&1; then
+  run_jvm_hygiene_checks "Try-with-resources / use {} coverage" "Closeable resources appear wrapped in try-with-resources or use {}" java.resource.closeable-no-twr
 else
-  print_finding "good" "Closeable resources appear wrapped in try-with-resources"
+  print_subheader "Try-with-resources coverage"
+  twr_candidates=$("${GREP_RN[@]}" -e "new[[:space:]]+(File(Input|Output)Stream|Buffered(Reader|Writer)|Scanner|FileReader|FileWriter|Connection|PreparedStatement)\(" "$PROJECT_DIR" 2>/dev/null | grep -vE "try[[:space:]]*\\(" | count_lines || true)
+  if [ "$twr_candidates" -gt 0 ]; then
+    print_finding "warning" "$twr_candidates" "Closeable created outside try-with-resources" "Wrap AutoCloseable objects in try-with-resources or close them in finally blocks"
+    show_detailed_finding "new[[:space:]]+(File(Input|Output)Stream|Buffered(Reader|Writer)|Scanner|Connection|PreparedStatement)\(" 3
+  else
+    print_finding "good" "Closeable resources appear wrapped in try-with-resources"
+  fi
 fi
 fi
 
@@ -3606,7 +3997,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if should_run 6; then
 print_header "6. LOGGING & DEBUGGING"
-print_category "Detects: System.out/err.println, printStackTrace, TODO/FIXME/HACK markers" \
+print_category "Detects: System.out/err.println, printStackTrace, swallowed broad catches, TODO/FIXME/HACK markers" \
   "Debug code left in production affects performance and leaks info"
 
 print_subheader "System.out/err.println"
@@ -3617,6 +4008,8 @@ print_subheader "printStackTrace calls"
 pst_cnt=$(( $(ast_search '$E.printStackTrace()' || echo 0) + $("${GREP_RN[@]}" -e "\.printStackTrace\(" "$PROJECT_DIR" 2>/dev/null | count_lines || true) ))
 if [ "$pst_cnt" -gt 0 ]; then print_finding "warning" "$pst_cnt" "printStackTrace leaks details"; fi
 
+run_jvm_hygiene_checks "Broad catch blocks that swallow errors" "No swallowed catch (Exception/Throwable) blocks" java.exception.broad-catch-swallowed
+
 print_subheader "Technical debt markers"
 todo_count=$("${GREP_RNI[@]}" "TODO" "$PROJECT_DIR" 2>/dev/null | count_lines || true)
 fixme_count=$("${GREP_RNI[@]}" "FIXME" "$PROJECT_DIR" 2>/dev/null | count_lines || true)
@@ -3763,31 +4156,35 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if should_run 13; then
 print_header "13. SQL CONSTRUCTION (HEURISTICS)"
-print_category "Detects: string-concatenated SQL, Statement.executeQuery with + operator" \
+print_category "Detects: string-concatenated SQL, Kotlin string templates and String.format in SQL, Statement.executeQuery with + operator" \
   "Prefer prepared statements with parameters to avoid injection"
 
-print_subheader "String-concatenated SQL"
-sql_concat=$("${GREP_RN[@]}" -e "\"(SELECT|INSERT|UPDATE|DELETE)[^\"]*\"[[:space:]]*\\+[[:space:]]*[A-Za-z0-9_]" "$PROJECT_DIR" 2>/dev/null | count_lines || true)
-if [ "$sql_concat" -gt 0 ]; then
-  print_finding "warning" "$sql_concat" "SQL built via concatenation - prefer parameters"
-  show_detailed_finding "execute(Query|Update)\s*\([^)]*\+" 3
-fi
+if command -v python3 >/dev/null 2>&1; then
+  run_jvm_hygiene_checks "SQL built from strings (concatenation, templates, format)" "SQL text is built without splicing in values" java.sql.string-built
+else
+  print_subheader "String-concatenated SQL"
+  sql_concat=$("${GREP_RN[@]}" -e "\"(SELECT|INSERT|UPDATE|DELETE)[^\"]*\"[[:space:]]*\\+[[:space:]]*[A-Za-z0-9_]" "$PROJECT_DIR" 2>/dev/null | count_lines || true)
+  if [ "$sql_concat" -gt 0 ]; then
+    print_finding "warning" "$sql_concat" "SQL built via concatenation - prefer parameters"
+    show_detailed_finding "execute(Query|Update)\s*\([^)]*\+" 3
+  fi
 
-print_subheader "Statement.executeQuery with concatenation"
-exec_concat=$("${GREP_RN[@]}" -e "execute(Query|Update)\s*\(" "$PROJECT_DIR" 2>/dev/null | (grep "\+" || true) | count_lines)
-if [ "$exec_concat" -gt 0 ]; then
-  print_finding "warning" "$exec_concat" "execute* called with concatenated query string"
-  show_detailed_finding "execute(Query|Update)\s*\([^)]*\+" 3
-elif [ "$sql_concat" -eq 0 ]; then
-  mapfile -t sql_meta < <(java_pattern_scan sql_concat)
-  sql_fallback="${sql_meta[0]:-0}"
-  sql_samples="${sql_meta[1]:-}"
-  if [ "${sql_fallback:-0}" -gt 0 ]; then
-    sql_desc="Prefer PreparedStatement parameters over string concatenation"
-    if [ -n "$sql_samples" ]; then
-      sql_desc+=" (e.g., ${sql_samples%%,*})"
+  print_subheader "Statement.executeQuery with concatenation"
+  exec_concat=$("${GREP_RN[@]}" -e "execute(Query|Update)\s*\(" "$PROJECT_DIR" 2>/dev/null | (grep "\+" || true) | count_lines)
+  if [ "$exec_concat" -gt 0 ]; then
+    print_finding "warning" "$exec_concat" "execute* called with concatenated query string"
+    show_detailed_finding "execute(Query|Update)\s*\([^)]*\+" 3
+  elif [ "$sql_concat" -eq 0 ]; then
+    mapfile -t sql_meta < <(java_pattern_scan sql_concat)
+    sql_fallback="${sql_meta[0]:-0}"
+    sql_samples="${sql_meta[1]:-}"
+    if [ "${sql_fallback:-0}" -gt 0 ]; then
+      sql_desc="Prefer PreparedStatement parameters over string concatenation"
+      if [ -n "$sql_samples" ]; then
+        sql_desc+=" (e.g., ${sql_samples%%,*})"
+      fi
+      print_finding "warning" "$sql_fallback" "SQL built via concatenation - prefer parameters" "$sql_desc"
     fi
-    print_finding "warning" "$sql_fallback" "SQL built via concatenation - prefer parameters" "$sql_desc"
   fi
 fi
 fi
@@ -0,0 +1,1052 @@
+#!/usr/bin/env bash
+# ═══════════════════════════════════════════════════════════════════════════
+# PROTO ULTIMATE BUG SCANNER v1.0.0 (Bash) - gRPC/Protobuf Contract Analysis
+# ═══════════════════════════════════════════════════════════════════════════
+# Static analysis for `.proto` contracts using a small python3 parser:
+#   • messages, enums (nested too), oneofs, maps, reserved ranges/names
+#   • services and rpc streaming modes
+#   • optional baseline: a FileDescriptorSet (protoc --descriptor_set_out,
+#     buf build -o) or a directory of .proto sources from the last release
+#
+# Focus:
+#   • reserved number/name reuse     • deleted fields left unreserved
+#   • wire-breaking type changes     • removed or reshaped RPCs
+# Go call sites that drop stream Send/Recv errors are covered by ubs-golang.
+#
+# Supports:
+#   --format text|json|sarif (json/sarif => pure machine output)
+#   --fail-on-warning, --skip, --only, --jobs, --include-ext, --exclude
+#   --ci, --no-color, --summary-json
+# ═══════════════════════════════════════════════════════════════════════════
+
+if [ "${BASH_VERSINFO[0]:-0}" -lt 4 ]; then
+  echo "ERROR: ubs-proto.sh requires bash >= 4.0 (you have ${BASH_VERSION:-unknown})." >&2
+  echo "       On macOS: 'brew install bash' and re-run via /opt/homebrew/bin/bash." >&2
+  exit 2
+fi
+
+set -Eeuo pipefail
+umask 022
+shopt -s lastpipe
+shopt -s extglob
+
+SCRIPT_DIR="$(cd -- "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+
+# ────────────────────────────────────────────────────────────────────────────
+# Globals & defaults
+# ────────────────────────────────────────────────────────────────────────────
+
+VERBOSE=0
+PROJECT_DIR="."
+OUTPUT_FILE=""
+FORMAT="text"          # text|json|sarif
+CI_MODE=0
+FAIL_ON_WARNING=0
+INCLUDE_EXT="proto"
+QUIET=0
+NO_COLOR_FLAG=0
+EXTRA_EXCLUDES=""
+SKIP_CATEGORIES=""
+ONLY_CATEGORIES=""
+DETAIL_LIMIT=3
+MAX_DETAILED=250
+JOBS="${JOBS:-0}"
+
+# Baseline contract to diff against: a FileDescriptorSet file or a directory
+# of .proto sources. Without one only the in-tree hygiene checks run.
+PROTO_BASELINE="${UBS_PROTO_BASELINE:-}"
+
+SUMMARY_JSON=""
+
+CHECK="✓"; CROSS="✗"; WARN="⚠"; INFO="ℹ"; ARROW="→"; BULLET="•"; FIRE="🔥"; SPARKLE="✨"; SHIELD="🛡"
+
+# Color handling
+USE_COLOR=1
+if [[ -n "${NO_COLOR:-}" || ! -t 1 ]]; then USE_COLOR=0; fi
+if [[ "$USE_COLOR" -eq 1 ]]; then
+  RED='\033[0;31m'; GREEN='\033[0;32m'; YELLOW='\033[1;33m'; BLUE='\033[0;34m'
+  MAGENTA='\033[0;35m'; CYAN='\033[0;36m'; WHITE='\033[1;37m'; GRAY='\033[0;90m'
+  BOLD='\033[1m'; DIM='\033[2m'; RESET='\033[0m'
+else
+  RED=''; GREEN=''; YELLOW=''; BLUE=''; MAGENTA=''; CYAN=''; WHITE=''; GRAY=''
+  BOLD=''; DIM=''; RESET=''
+fi
+
+# ────────────────────────────────────────────────────────────────────────────
+# Error handling
+# ────────────────────────────────────────────────────────────────────────────
+
+on_err() {
+  local ec=$?; local cmd=${BASH_COMMAND}; local line=${BASH_LINENO[0]}; local src=${BASH_SOURCE[1]:-${BASH_SOURCE[0]}}
+  if [[ "${FORMAT:-text}" == "json" || "${FORMAT:-text}" == "sarif" ]]; then
+    echo "{\"error\":{\"exit\":$ec,\"file\":\"$src\",\"line\":$line,\"cmd\":\"${cmd//\"/\\\"}\"}}" >&2; exit "$ec"
+  fi
+  echo -e "\n${RED}${BOLD}Unexpected error (exit $ec)${RESET} ${DIM}at ${src}:${line}${RESET}\n${DIM}Last command:${RESET} ${WHITE}$cmd${RESET}" >&2
+  exit "$ec"
+}
+trap on_err ERR
+
+print_usage() {
+  cat >&2 <<USAGE
+Usage: $(basename "$0") [options] [PROJECT_DIR] [OUTPUT_FILE]
+
+Options:
+  -v, --verbose            More code samples per finding (DETAIL=10)
+  --very-verbose           Max code samples (DETAIL=25)
+  -q, --quiet              Reduce non-essential output
+  --format=FMT             Output format: text|json|sarif (default: text)
+  --summary-json=FILE      Save brief summary counters JSON
+  --ci                     CI mode (no clear, stable timestamps)
+  --no-color               Force disable ANSI color
+  --include-ext=CSV        File extensions (default: $INCLUDE_EXT)
+  --exclude=GLOB[,..]      Additional glob(s)/dir(s) to exclude
+  --only=CSV               Only run these category numbers
+  --jobs=N                 Accepted for meta-runner compatibility
+  --skip=CSV               Skip categories by number (e.g. --skip=2)
+  --fail-on-warning        Exit non-zero on warnings or critical
+  --baseline=PATH          Descriptor set or .proto directory to diff against
+  -h, --help               Show help
+Env:
+  JOBS, NO_COLOR, CI, UBS_METRICS_DIR, UBS_PROTO_BASELINE
+Args:
+  PROJECT_DIR              Directory to scan (default: ".")
+  OUTPUT_FILE              File to save the report (optional)
+USAGE
+}
+
+# CLI parsing
+while [[ $# -gt 0 ]]; do
+  case "$1" in
+    -v|--verbose) VERBOSE=1; DETAIL_LIMIT=10; shift;;
+    --very-verbose) VERBOSE=2; DETAIL_LIMIT=25; shift;;
+    -q|--quiet)   VERBOSE=0; DETAIL_LIMIT=1; QUIET=1; shift;;
+    --format=*)   FORMAT="${1#*=}"; shift;;
+    --summary-json=*) SUMMARY_JSON="${1#*=}"; shift;;
+    --ci)         CI_MODE=1; shift;;
+    --no-color)   NO_COLOR_FLAG=1; shift;;
+    --include-ext=*) INCLUDE_EXT="${1#*=}"; shift;;
+    --exclude=*)  EXTRA_EXCLUDES="${1#*=}"; shift;;
+    --only=*)     ONLY_CATEGORIES="${1#*=}"; shift;;
+    --jobs=*)     JOBS="${1#*=}"; shift;;
+    --skip=*)     SKIP_CATEGORIES="${1#*=}"; shift;;
+    --fail-on-warning) FAIL_ON_WARNING=1; shift;;
+    --baseline=*) PROTO_BASELINE="${1#*=}"; shift;;
+    -h|--help)    print_usage; exit 0;;
+    *)
+      if [[ -z "$PROJECT_DIR" || "$PROJECT_DIR" == "." ]] && ! [[ "$1" =~ ^- ]]; then
+        PROJECT_DIR="$1"; shift
+      elif [[ -z "$OUTPUT_FILE" ]] && ! [[ "$1" =~ ^- ]]; then
+        if [[ -e "$1" && -s "$1" ]]; then
+          echo "error: refusing to use existing non-empty file '$1' as OUTPUT_FILE (would be overwritten)." >&2
+          echo "       To scan multiple paths, use the meta-runner 'ubs'. To save a report, pass a fresh (non-existing) path." >&2
+          exit 2
+        fi
+        OUTPUT_FILE="$1"; shift
+      else
+        echo "Unexpected argument: $1" >&2; exit 2
+      fi
+      ;;
+  esac
+done
+
+# CI auto-detect + color override
+if [[ -n "${CI:-}" ]]; then CI_MODE=1; fi
+if [[ "$NO_COLOR_FLAG" -eq 1 ]]; then
+  USE_COLOR=0
+  RED=''; GREEN=''; YELLOW=''; BLUE=''; MAGENTA=''; CYAN=''; WHITE=''; GRAY=''
+  BOLD=''; DIM=''; RESET=''
+fi
+
+# Redirect output early to capture everything (honors machine formats too)
+if [[ -n "${OUTPUT_FILE}" ]]; then
+  if command -v tee >/dev/null 2>&1; then
+    exec > >(tee "${OUTPUT_FILE}") 2>&1
+  else
+    exec > "${OUTPUT_FILE}" 2>&1
+  fi
+fi
+
+DATE_FMT='%Y-%m-%d %H:%M:%S'
+safe_date() {
+  if [[ "$CI_MODE" -eq 1 ]]; then
+    command date -u '+%Y-%m-%dT%H:%M:%SZ' 2>/dev/null || command date '+%Y-%m-%dT%H:%M:%SZ'
+  else
+    command date "+$DATE_FMT"
+  fi
+}
+is_machine_format(){ [[ "$FORMAT" == "json" || "$FORMAT" == "sarif" ]]; }
+
+# If machine format: silence all user-facing text immediately.
+if is_machine_format; then
+  QUIET=1
+  USE_COLOR=0
+fi
+
+# ────────────────────────────────────────────────────────────────────────────
+# Global Counters
+# ────────────────────────────────────────────────────────────────────────────
+CRITICAL_COUNT=0
+WARNING_COUNT=0
+INFO_COUNT=0
+TOTAL_FILES=0
+
+# ────────────────────────────────────────────────────────────────────────────
+# Utilities
+# ────────────────────────────────────────────────────────────────────────────
+maybe_clear() { if [[ -t 1 && "$CI_MODE" -eq 0 ]] && ! is_machine_format; then clear || true; fi; }
+say() { [[ "$QUIET" -eq 1 ]] && return 0; echo -e "$*"; }
+
+json_escape() {
+  local s="${1-}"
+  s=${s//\\/\\\\}
+  s=${s//\"/\\\"}
+  s=${s//$'\n'/\\n}
+  s=${s//$'\r'/\\r}
+  s=${s//$'\t'/\\t}
+  printf '%s' "$s"
+}
+
+emit_json_summary() {
+  local ts json
+  ts="$(safe_date)"
+  json="$(printf '{"project":"%s","files":%s,"critical":%s,"warning":%s,"info":%s,"timestamp":"%s","format":"json"}\n' \
+    "$(json_escape "$PROJECT_DIR")" "$TOTAL_FILES" "$CRITICAL_COUNT" "$WARNING_COUNT" "$INFO_COUNT" "$(json_escape "$ts")")"
+  printf '%s' "$json"
+  if [[ -n "$SUMMARY_JSON" ]]; then
+    mkdir -p "$(dirname "$SUMMARY_JSON")" 2>/dev/null || true
+    printf '%s' "$json" >"$SUMMARY_JSON"
+  fi
+}
+
+emit_sarif() {
+  printf '%s\n' '{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"ubs-proto"}},"results":[]}]}'
+}
+print_header() { say "\n${CYAN}${BOLD}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${RESET}"; say "${WHITE}${BOLD}$1${RESET}"; say "${CYAN}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${RESET}"; }
+print_category() { say "\n${MAGENTA}${BOLD}▓▓▓ $1${RESET}"; say "${DIM}$2${RESET}"; }
+print_subheader() { say "\n${YELLOW}${BOLD}$BULLET $1${RESET}"; }
+print_finding() {
+  local severity=$1
+  case $severity in
+    good) local title=$2; say "  ${GREEN}${CHECK} OK${RESET} ${DIM}$title${RESET}" ;;
+    *)
+      local raw_count=$2; local title=$3; local description="${4:-}"
+      local count; count=$(printf '%s\n' "$raw_count" | awk 'END{print $0+0}')
+      case $severity in
+        critical) CRITICAL_COUNT=$((CRITICAL_COUNT + count)); say "  ${RED}${BOLD}${FIRE} CRITICAL${RESET} ${WHITE}($count found)${RESET}"; say "    ${RED}${BOLD}$title${RESET}"; [ -n "$description" ] && say "    ${DIM}$description${RESET}" || true ;;
+        warning)  WARNING_COUNT=$((WARNING_COUNT + count)); say "  ${YELLOW}${WARN} Warning${RESET} ${WHITE}($count found)${RESET}"; say "    ${YELLOW}$title${RESET}"; [ -n "$description" ] && say "    ${DIM}$description${RESET}" || true ;;
+        info)     INFO_COUNT=$((INFO_COUNT + count));      say "  ${BLUE}${INFO} Info${RESET} ${WHITE}($count found)${RESET}"; say "    ${BLUE}$title${RESET}"; [ -n "$description" ] && say "    ${DIM}$description${RESET}" || true ;;
+      esac
+      ;;
+  esac
+}
+print_code_sample() { local file=$1; local line=$2; local code=$3; say "${GRAY}      $file:$line${RESET}"; say "${WHITE}      $code${RESET}"; }
+
+begin_scan_section(){ set +o pipefail; set +e; trap - ERR; }
+end_scan_section(){ trap on_err ERR; set -e; set -o pipefail; }
+
+mktemp_file(){ mktemp 2>/dev/null || mktemp -t ubs-proto.XXXXXX; }
+
+# Path helpers & robust file discovery
+abspath() { perl -MCwd=abs_path -e 'print abs_path(shift)' -- "$1" 2>/dev/null || python3 - "$1" <<'PY'
+import os,sys; print(os.path.abspath(sys.argv[1]))
+PY
+}
+
+LC_ALL=C
+IFS=',' read -r -a _EXT_ARR <<<"$INCLUDE_EXT"
+EXCLUDE_DIRS=(.git .hg .svn .bzr node_modules vendor dist build target .venv venv .cache .idea .vscode .history tmp log)
+if [[ -n "$EXTRA_EXCLUDES" ]]; then IFS=',' read -r -a _X <<<"$EXTRA_EXCLUDES"; EXCLUDE_DIRS+=("${_X[@]}"); fi
+
+build_find_cmd() {
+  local -a prune=( )
+  for d in "${EXCLUDE_DIRS[@]}"; do prune+=( -name "$d" -o ); done
+  [[ ${#prune[@]} -gt 0 ]] && unset 'prune[${#prune[@]}-1]'
+  local -a names=( ); local first=1
+  for e in "${_EXT_ARR[@]}"; do if [[ $first -eq 1 ]]; then names+=( -name "*.$e" ); first=0; else names+=( -o -name "*.$e" ); fi; done
+  FIND_CMD=(find "$PROJECT_DIR" \( -type d \( "${prune[@]}" \) -prune \) -o \( -type f \( "${names[@]}" \) -print0 \))
+}
+safe_count_files(){ tr -cd '\0' | awk 'END{print (length>0?gsub(/\0/,"")+0:0)}'; }
+
+# Category gating (run if returns 0)
+run_category() {
+  local cat="$1"
+  if [[ -n "$ONLY_CATEGORIES" ]]; then
+    IFS=',' read -r -a arr <<<"$ONLY_CATEGORIES"
+    for s in "${arr[@]}"; do [[ "$s" == "$cat" ]] && return 0; done
+    return 1
+  fi
+  if [[ -z "$SKIP_CATEGORIES" ]]; then return 0; fi
+  IFS=',' read -r -a arr <<<"$SKIP_CATEGORIES"
+  for s in "${arr[@]}"; do [[ "$s" == "$cat" ]] && return 1; done
+  return 0
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Contract analyzer
+# ────────────────────────────────────────────────────────────────────────────
+# One python3 pass parses every .proto file (and the optional baseline, either
+# a FileDescriptorSet from `protoc --descriptor_set_out` / `buf build -o` or a
+# directory of .proto sources) into messages/enums/services, then emits
+# `rule<TAB>path<TAB>line<TAB>detail` rows into PROTO_FINDINGS_FILE.
+
+PROTO_RULE_IDS=(
+  proto.field.reserved-reuse
+  proto.field.gap-not-reserved
+  proto.baseline.field-removed-not-reserved
+  proto.baseline.field-number-reused
+  proto.baseline.field-type-changed
+  proto.baseline.field-renamed
+  proto.baseline.definition-removed
+  proto.baseline.rpc-signature-changed
+)
+
+declare -A PROTO_SUMMARY=(
+  [proto.field.reserved-reuse]="Field or enum value reuses a reserved number/name"
+  [proto.field.gap-not-reserved]="Field-number gap not covered by reserved"
+  [proto.baseline.field-removed-not-reserved]="Field or enum value removed without reserved"
+  [proto.baseline.field-number-reused]="Removed field number reused by a different field"
+  [proto.baseline.field-type-changed]="Field type or cardinality changed"
+  [proto.baseline.field-renamed]="Field renamed (breaks JSON/text format clients)"
+  [proto.baseline.definition-removed]="Message, enum, service, or RPC removed"
+  [proto.baseline.rpc-signature-changed]="RPC request/response type or streaming mode changed"
+)
+
+declare -A PROTO_REMEDIATION=(
+  [proto.field.reserved-reuse]="Numbers and names listed in reserved belonged to deleted fields; old binaries still decode them with the old meaning. Pick a fresh number."
+  [proto.field.gap-not-reserved]="If the missing numbers belonged to deleted fields, add 'reserved N;' (and the old names) so nobody reuses them."
+  [proto.baseline.field-removed-not-reserved]="Add 'reserved N;' and 'reserved \"old_name\";' for every deleted field or enum value so the number can never be reused."
+  [proto.baseline.field-number-reused]="Old clients will decode the new field with the old field's type and meaning. Restore the old field or move the new one to an unused number."
+  [proto.baseline.field-type-changed]="Changing wire type or repeated/singular breaks deployed peers; add a new field and deprecate the old one instead."
+  [proto.baseline.field-renamed]="Binary encoding is unaffected, but JSON and text-format payloads use field names. Keep the old name or use json_name to preserve it."
+  [proto.baseline.definition-removed]="Deployed clients still call or reference it. Deprecate first and remove only after every consumer has migrated."
+  [proto.baseline.rpc-signature-changed]="Add a new RPC with the new shape and deprecate the old one; existing stubs cannot talk to the changed method."
+)
+
+declare -A PROTO_SEVERITY=(
+  [proto.field.reserved-reuse]="critical"
+  [proto.field.gap-not-reserved]="info"
+  [proto.baseline.field-removed-not-reserved]="critical"
+  [proto.baseline.field-number-reused]="critical"
+  [proto.baseline.field-type-changed]="critical"
+  [proto.baseline.field-renamed]="warning"
+  [proto.baseline.definition-removed]="critical"
+  [proto.baseline.rpc-signature-changed]="critical"
+)
+
+PROTO_FINDINGS_FILE=""
+
+run_proto_analyzer() {
+  PROTO_FINDINGS_FILE="$(mktemp_file)"
+  if ! command -v python3 >/dev/null 2>&1; then
+    return 1
+  fi
+  python3 - "$PROJECT_DIR" "$PROTO_BASELINE" "${EXCLUDE_DIRS[*]}" "$INCLUDE_EXT" >"$PROTO_FINDINGS_FILE" <<'PY' || true
+import re
+import sys
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+BASELINE = sys.argv[2]
+SKIP_DIRS = set(sys.argv[3].split())
+EXTS = {'.' + e.strip().lstrip('.').lower() for e in sys.argv[4].split(',') if e.strip()}
+
+FIELD_MAX = 536870911
+ENUM_MAX = 2147483647
+SCALARS = {
+    1: 'double', 2: 'float', 3: 'int64', 4: 'uint64', 5: 'int32', 6: 'fixed64', 7: 'fixed32',
+    8: 'bool', 9: 'string', 12: 'bytes', 13: 'uint32', 15: 'sfixed32', 16: 'sfixed64',
+    17: 'sint32', 18: 'sint64',
+}
+TOKEN_RE = re.compile(r'"(?:\\.|[^"\\])*"|\'(?:\\.|[^\'\\])*\'|[A-Za-z_.][A-Za-z0-9_.]*|-?\d+|[{}\[\]()<>;=,:]')
+
+
+def should_skip(path: Path, root: Path) -> bool:
+    try:
+        parts = path.relative_to(root).parts
+    except ValueError:
+        parts = path.parts
+    return any(part in SKIP_DIRS for part in parts[:-1])
+
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() in EXTS:
+            yield root
+        return
+    for path in sorted(root.rglob('*')):
+        if path.is_file() and path.suffix.lower() in EXTS and not should_skip(path, root):
+            yield path
+
+
+def relpath(path, base=BASE_DIR):
+    try:
+        return str(Path(path).resolve().relative_to(base))
+    except ValueError:
+        return str(path)
+
+
+def strip_comments(text):
+    out, i, n = [], 0, len(text)
+    while i < n:
+        if text.startswith('//', i):
+            j = text.find('\n', i)
+            i = n if j < 0 else j
+        elif text.startswith('/*', i):
+            j = text.find('*/', i + 2)
+            j = n if j < 0 else j + 2
+            out.append('\n' * text.count('\n', i, j))
+            i = j
+        elif text[i] in '"\'':
+            q, j = text[i], i + 1
+            while j < n and text[j] != q and text[j] != '\n':
+                j += 2 if text[j] == '\\' else 1
+            out.append(text[i:j + 1])
+            i = j + 1
+        else:
+            out.append(text[i])
+            i += 1
+    return ''.join(out)
+
+
+def tokenize(text):
+    tokens = []
+    for line_no, line in enumerate(strip_comments(text).split('\n'), start=1):
+        for m in TOKEN_RE.finditer(line):
+            tokens.append((m.group(0), line_no))
+    return tokens
+
+
+def camel_entry(name):
+    return ''.join(part[:1].upper() + part[1:] for part in name.split('_')) + 'Entry'
+
+
+def short_type(name):
+    return name.lstrip('.').rsplit('.', 1)[-1]
+
+
+def in_ranges(num, ranges):
+    return any(lo <= num <= hi for lo, hi in ranges)
+
+
+class Model:
+    def __init__(self):
+        self.messages = {}
+        self.enums = {}
+        self.services = {}
+
+
+def new_message(path, line):
+    return {'path': path, 'line': line, 'fields': {}, 'reserved': [], 'reserved_names': set(), 'extensions': []}
+
+
+class Parser:
+    def __init__(self, tokens, path, model):
+        self.t, self.i, self.path, self.model = tokens, 0, path, model
+        self.package = ''
+
+    def peek(self, k=0):
+        j = self.i + k
+        return self.t[j][0] if j < len(self.t) else None
+
+    def line(self):
+        return self.t[self.i][1] if self.i < len(self.t) else 0
+
+    def take(self):
+        tok = self.t[self.i][0] if self.i < len(self.t) else None
+        self.i += 1
+        return tok
+
+    def skip_statement(self):
+        depth = 0
+        while self.i < len(self.t):
+            tok = self.take()
+            if tok == '{':
+                depth += 1
+            elif tok == '}':
+                depth -= 1
+                if depth <= 0:
+                    return
+            elif tok == ';' and depth == 0:
+                return
+
+    def skip_block(self):
+        while self.i < len(self.t) and self.peek() != '{':
+            self.take()
+        self.skip_statement()
+
+    def skip_options(self):
+        if self.peek() == '[':
+            depth = 0
+            while self.i < len(self.t):
+                tok = self.take()
+                if tok == '[':
+                    depth += 1
+                elif tok == ']':
+                    depth -= 1
+                    if depth == 0:
+                        break
+
+    def qualify(self, scope, name):
+        return '.'.join(p for p in (self.package, scope, name) if p)
+
+    def parse(self):
+        while self.i < len(self.t):
+            tok = self.peek()
+            if tok == 'package':
+                self.take()
+                self.package = self.take() or ''
+                self.skip_statement()
+            elif tok == 'message':
+                self.parse_message('')
+            elif tok == 'enum':
+                self.parse_enum('')
+            elif tok == 'service':
+                self.parse_service()
+            elif tok == 'extend':
+                self.skip_block()
+            else:
+                self.skip_statement()
+
+    def parse_reserved(self, msg, max_value):
+        self.take()
+        while self.i < len(self.t) and self.peek() != ';':
+            tok = self.take()
+            if tok[0] in '"\'':
+                msg['reserved_names'].add(tok[1:-1])
+            elif re.fullmatch(r'-?\d+', tok):
+                lo = hi = int(tok)
+                if self.peek() == 'to':
+                    self.take()
+                    end = self.take()
+                    hi = max_value if end == 'max' else int(end)
+                msg['reserved'].append((lo, hi))
+            elif re.fullmatch(r'[A-Za-z_]\w*', tok):
+                msg['reserved_names'].add(tok)
+        self.take()
+
+    def parse_message(self, scope):
+        line = self.line()
+        self.take()
+        name = self.take()
+        fq = self.qualify(scope, name)
+        inner = f'{scope}.{name}' if scope else name
+        msg = new_message(self.path, line)
+        self.model.messages[fq] = msg
+        self.take()  # {
+        self.parse_message_body(msg, inner)
+
+    def parse_message_body(self, msg, inner):
+        while self.i < len(self.t):
+            tok = self.peek()
+            if tok == '}':
+                self.take()
+                return
+            if tok == 'message':
+                self.parse_message(inner)
+            elif tok == 'enum':
+                self.parse_enum(inner)
+            elif tok == 'oneof':
+                self.take()
+                self.take()
+                self.take()  # {
+                self.parse_message_body(msg, inner)
+            elif tok == 'reserved':
+                self.parse_reserved(msg, FIELD_MAX)
+            elif tok == 'extensions':
+                self.take()
+                while self.i < len(self.t) and self.peek() != ';':
+                    lo = self.take()
+                    if re.fullmatch(r'\d+', lo or ''):
+                        hi = lo
+                        if self.peek() == 'to':
+                            self.take()
+                            hi = self.take()
+                            hi = FIELD_MAX if hi == 'max' else hi
+                        msg['extensions'].append((int(lo), int(hi)))
+                self.skip_statement()
+            elif tok == 'extend':
+                self.skip_block()
+            elif tok in ('option', ';'):
+                self.skip_statement()
+            elif tok == 'map' and self.peek(1) == '<':
+                line = self.line()
+                while self.i < len(self.t) and self.peek() != '>':
+                    self.take()
+                self.take()
+                fname = self.take()
+                self.take()  # =
+                num = self.take()
+                self.skip_options()
+                self.skip_statement()
+                if num and re.fullmatch(r'\d+', num):
+                    msg['fields'][int(num)] = {'name': fname, 'type': camel_entry(fname), 'label': 'repeated', 'line': line}
+            else:
+                line = self.line()
+                label = 'singular'
+                if tok in ('optional', 'required', 'repeated'):
+                    label = 'repeated' if self.take() == 'repeated' else 'singular'
+                ftype = self.take()
+                if ftype == 'group':
+                    self.skip_block()
+                    continue
+                fname = self.take()
+                if self.peek() != '=':
+                    self.skip_statement()
+                    continue
+                self.take()
+                num = self.take()
+                self.skip_options()
+                self.skip_statement()
+                if num and re.fullmatch(r'\d+', num):
+                    msg['fields'][int(num)] = {'name': fname, 'type': short_type(ftype), 'label': label, 'line': line}
+
+    def parse_enum(self, scope):
+        line = self.line()
+        self.take()
+        name = self.take()
+        enum = new_message(self.path, line)
+        self.model.enums[self.qualify(scope, name)] = enum
+        self.take()  # {
+        while self.i < len(self.t):
+            tok = self.peek()
+            if tok == '}':
+                self.take()
+                return
+            if tok == 'reserved':
+                self.parse_reserved(enum, ENUM_MAX)
+            elif tok in ('option', ';'):
+                self.skip_statement()
+            else:
+                vline = self.line()
+                vname = self.take()
+                if self.peek() != '=':
+                    self.skip_statement()
+                    continue
+                self.take()
+                num = self.take()
+                self.skip_options()
+                self.skip_statement()
+                if num and re.fullmatch(r'-?\d+', num):
+                    enum['fields'].setdefault(int(num), {'name': vname, 'type': 'enum', 'label': 'singular', 'line': vline})
+
+    def parse_service(self):
+        line = self.line()
+        self.take()
+        name = self.take()
+        svc = {'path': self.path, 'line': line, 'methods': {}}
+        self.model.services[self.qualify('', name)] = svc
+        self.take()  # {
+        while self.i < len(self.t):
+            tok = self.peek()
+            if tok == '}':
+                self.take()
+                return
+            if tok != 'rpc':
+                self.skip_statement()
+                continue
+            mline = self.line()
+            self.take()
+            mname = self.take()
+            self.take()  # (
+            cs = self.peek() == 'stream'
+            if cs:
+                self.take()
+            req = self.take()
+            self.take()  # )
+            self.take()  # returns
+            self.take()  # (
+            ss = self.peek() == 'stream'
+            if ss:
+                self.take()
+            resp = self.take()
+            self.take()  # )
+            self.skip_statement()
+            svc['methods'][mname] = {'in': short_type(req or ''), 'out': short_type(resp or ''), 'cs': cs, 'ss': ss, 'line': mline}
+
+
+# ── FileDescriptorSet (binary) decoding ───────────────────────────────────────
+
+def read_varint(buf, i):
+    shift = result = 0
+    while True:
+        b = buf[i]
+        i += 1
+        result |= (b & 0x7F) << shift
+        if not b & 0x80:
+            return result, i
+        shift += 7
+
+
+def fields_of(buf):
+    i, n = 0, len(buf)
+    while i < n:
+        key, i = read_varint(buf, i)
+        num, wt = key >> 3, key & 7
+        if wt == 0:
+            val, i = read_varint(buf, i)
+        elif wt == 1:
+            val, i = buf[i:i + 8], i + 8
+        elif wt == 2:
+            ln, i = read_varint(buf, i)
+            val, i = buf[i:i + ln], i + ln
+        elif wt == 5:
+            val, i = buf[i:i + 4], i + 4
+        else:
+            raise ValueError('unsupported wire type')
+        yield num, val
+
+
+def first(buf, num, default=None):
+    for n, v in fields_of(buf):
+        if n == num:
+            return v
+    return default
+
+
+def text(v):
+    return v.decode('utf-8', 'replace') if isinstance(v, (bytes, bytearray)) else ''
+
+
+def load_descriptor_set(data, label, model):
+    for num, fbuf in fields_of(data):
+        if num != 1:
+            continue
+        fname = text(first(fbuf, 1, b'')) or label
+        package = text(first(fbuf, 2, b''))
+        path = f'{label}:{fname}'
+
+        def add_message(buf, prefix):
+            name = text(first(buf, 1, b''))
+            fq = f'{prefix}.{name}' if prefix else name
+            opts = first(buf, 7)
+            if opts is not None and first(opts, 7, 0):
+                return
+            msg = new_message(path, 0)
+            model.messages[fq] = msg
+            for n, v in fields_of(buf):
+                if n == 2:
+                    fnum = first(v, 3, 0)
+                    ftype = first(v, 5, 0)
+                    tname = short_type(text(first(v, 6, b''))) if ftype in (10, 11, 14) else SCALARS.get(ftype, str(ftype))
+                    msg['fields'][fnum] = {'name': text(first(v, 1, b'')), 'type': tname,
+                                           'label': 'repeated' if first(v, 4, 1) == 3 else 'singular', 'line': 0}
+                elif n == 3:
+                    add_message(v, fq)
+                elif n == 4:
+                    add_enum(v, fq)
+                elif n == 9:
+                    msg['reserved'].append((first(v, 1, 0), first(v, 2, 1) - 1))
+                elif n == 10:
+                    msg['reserved_names'].add(text(v))
+
+        def add_enum(buf, prefix):
+            name = text(first(buf, 1, b''))
+            fq = f'{prefix}.{name}' if prefix else name
+            enum = new_message(path, 0)
+            model.enums[fq] = enum
+            for n, v in fields_of(buf):
+                if n == 2:
+                    vnum = first(v, 2, 0)
+                    if vnum >= 1 << 63:
+                        vnum -= 1 << 64
+                    enum['fields'].setdefault(vnum, {'name': text(first(v, 1, b'')), 'type': 'enum', 'label': 'singular', 'line': 0})
+                elif n == 4:
+                    enum['reserved'].append((first(v, 1, 0), first(v, 2, 0)))
+                elif n == 5:
+                    enum['reserved_names'].add(text(v))
+
+        for n, v in fields_of(fbuf):
+            if n == 4:
+                add_message(v, package)
+            elif n == 5:
+                add_enum(v, package)
+            elif n == 6:
+                sname = text(first(v, 1, b''))
+                svc = {'path': path, 'line': 0, 'methods': {}}
+                model.services[f'{package}.{sname}' if package else sname] = svc
+                for mn, mv in fields_of(v):
+                    if mn == 2:
+                        svc['methods'][text(first(mv, 1, b''))] = {
+                            'in': short_type(text(first(mv, 2, b''))), 'out': short_type(text(first(mv, 3, b''))),
+                            'cs': bool(first(mv, 5, 0)), 'ss': bool(first(mv, 6, 0)), 'line': 0}
+
+
+def load_sources(root, model, base):
+    for path in iter_files(root):
+        try:
+            Parser(tokenize(path.read_text(encoding='utf-8', errors='ignore')), relpath(path, base), model).parse()
+        except (IndexError, ValueError):
+            continue
+
+
+def load_baseline(spec):
+    if not spec:
+        return None
+    path = Path(spec)
+    if not path.exists():
+        print(f"__ERROR__\t{spec}\t0\tbaseline not found")
+        return None
+    model = Model()
+    if path.is_dir() or path.suffix.lower() == '.proto':
+        base = path.resolve() if path.is_dir() else path.resolve().parent
+        load_sources(path.resolve(), model, base)
+    else:
+        try:
+            load_descriptor_set(path.read_bytes(), path.name, model)
+        except (IndexError, ValueError):
+            print(f"__ERROR__\t{spec}\t0\tbaseline is not a FileDescriptorSet")
+            return None
+    return model
+
+
+# ── Rules ────────────────────────────────────────────────────────────────────
+
+def emit(rule, path, line, detail):
+    print(f"{rule}\t{path}\t{max(line, 1)}\t{detail}")
+
+
+def has_ignore(lines_by_path, path, line):
+    lines = lines_by_path.get(path, [])
+    return any(0 <= k < len(lines) and 'ubs:ignore' in lines[k] for k in (line - 1, line - 2))
+
+
+current = Model()
+load_sources(ROOT, current, BASE_DIR)
+lines_by_path = {}
+for p in iter_files(ROOT):
+    lines_by_path[relpath(p)] = p.read_text(encoding='utf-8', errors='ignore').splitlines()
+
+for kind, table in (('message', current.messages), ('enum', current.enums)):
+    for fq, msg in sorted(table.items()):
+        for num, field in sorted(msg['fields'].items()):
+            if has_ignore(lines_by_path, msg['path'], field['line']):
+                continue
+            if in_ranges(num, msg['reserved']):
+                emit('proto.field.reserved-reuse', msg['path'], field['line'], f"{fq}.{field['name']} uses reserved number {num}")
+            elif field['name'] in msg['reserved_names']:
+                emit('proto.field.reserved-reuse', msg['path'], field['line'], f"{fq}.{field['name']} uses a reserved name")
+        if kind != 'message' or not msg['fields'] or has_ignore(lines_by_path, msg['path'], msg['line']):
+            continue
+        top = max(msg['fields'])
+        missing = [n for n in range(1, top) if n not in msg['fields'] and not in_ranges(n, msg['reserved'])
+                   and not in_ranges(n, msg['extensions']) and not 19000 <= n <= 19999]
+        if missing and len(missing) <= 32:
+            emit('proto.field.gap-not-reserved', msg['path'], msg['line'], f"{fq} skips {', '.join(map(str, missing))} without reserved")
+
+baseline = load_baseline(BASELINE)
+if baseline is not None:
+    for kind, old_table, new_table in (('message', baseline.messages, current.messages), ('enum', baseline.enums, current.enums)):
+        for fq, old in sorted(old_table.items()):
+            new = new_table.get(fq)
+            if new is None:
+                emit('proto.baseline.definition-removed', old['path'], old['line'], f"{kind} {fq} was removed")
+                continue
+            if has_ignore(lines_by_path, new['path'], new['line']):
+                continue
+            for num, of in sorted(old['fields'].items()):
+                nf = new['fields'].get(num)
+                where = f"{fq}.{of['name']} ({num})"
+                if nf is None:
+                    if not in_ranges(num, new['reserved']):
+                        emit('proto.baseline.field-removed-not-reserved', new['path'], new['line'], f"{where} removed without reserved")
+                    continue
+                if has_ignore(lines_by_path, new['path'], nf['line']):
+                    continue
+                renamed = nf['name'] != of['name']
+                retyped = nf['type'] != of['type'] or nf['label'] != of['label']
+                if kind == 'enum':
+                    if renamed:
+                        emit('proto.baseline.field-renamed', new['path'], nf['line'], f"{where} is now {nf['name']}")
+                elif renamed and retyped:
+                    emit('proto.baseline.field-number-reused', new['path'], nf['line'], f"{where} reused as {nf['label']} {nf['type']} {nf['name']}")
+                elif retyped:
+                    emit('proto.baseline.field-type-changed', new['path'], nf['line'], f"{where} changed {of['label']} {of['type']} -> {nf['label']} {nf['type']}")
+                elif renamed:
+                    emit('proto.baseline.field-renamed', new['path'], nf['line'], f"{where} is now {nf['name']}")
+    for fq, old in sorted(baseline.services.items()):
+        new = current.services.get(fq)
+        if new is None:
+            emit('proto.baseline.definition-removed', old['path'], old['line'], f"service {fq} was removed")
+            continue
+        for mname, om in sorted(old['methods'].items()):
+            nm = new['methods'].get(mname)
+            if nm is None:
+                emit('proto.baseline.definition-removed', new['path'], new['line'], f"rpc {fq}.{mname} was removed")
+            elif (nm['in'], nm['out'], nm['cs'], nm['ss']) != (om['in'], om['out'], om['cs'], om['ss']):
+                if has_ignore(lines_by_path, new['path'], nm['line']):
+                    continue
+                def shape(m):
+                    return f"({'stream ' if m['cs'] else ''}{m['in']}) returns ({'stream ' if m['ss'] else ''}{m['out']})"
+                emit('proto.baseline.rpc-signature-changed', new['path'], nm['line'], f"rpc {fq}.{mname} {shape(om)} -> {shape(nm)}")
+PY
+}
+
+# Report every rule id in "$@" from PROTO_FINDINGS_FILE; print the good message
+# when none of them fired.
+report_proto_rules() {
+  local good_msg="$1"; shift
+  local rule found=0
+  for rule in "$@"; do
+    local count
+    count=$(awk -F'\t' -v r="$rule" '$1==r' "$PROTO_FINDINGS_FILE" 2>/dev/null | awk 'END{print NR+0}')
+    [[ "$count" -gt 0 ]] || continue
+    found=1
+    print_finding "${PROTO_SEVERITY[$rule]}" "$count" "${PROTO_SUMMARY[$rule]}" "[$rule] ${PROTO_REMEDIATION[$rule]}"
+    local printed=0
+    while IFS=$'\t' read -r _rule file line detail; do
+      [[ "$printed" -ge "$DETAIL_LIMIT" || "$printed" -ge "$MAX_DETAILED" ]] && break
+      print_code_sample "$file" "$line" "$detail"
+      printed=$((printed + 1))
+    done < <(awk -F'\t' -v r="$rule" '$1==r' "$PROTO_FINDINGS_FILE" 2>/dev/null)
+  done
+  if [[ "$found" -eq 0 ]]; then
+    print_finding "good" "$good_msg"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Init
+# ────────────────────────────────────────────────────────────────────────────
+maybe_clear
+
+if ! is_machine_format; then
+echo -e "${BOLD}${CYAN}"
+cat <<'BANNER'
+╔══════════════════════════════════════════════════════════════════╗
+║  ██████╗ ██████╗  ██████╗ ████████╗ ██████╗                      ║
+║  ██╔══██╗██╔══██╗██╔═══██╗╚══██╔══╝██╔═══██╗                     ║
+║  ██████╔╝██████╔╝██║   ██║   ██║   ██║   ██║                     ║
+║  ██╔═══╝ ██╔══██╗██║   ██║   ██║   ██║   ██║                     ║
+║  ██║     ██║  ██║╚██████╔╝   ██║   ╚██████╔╝                     ║
+║  ╚═╝     ╚═╝  ╚═╝ ╚═════╝    ╚═╝    ╚═════╝                      ║
+║                                                                  ║
+║  Proto module • field numbers, reserved, baseline compatibility  ║
+║  Run standalone: modules/ubs-proto.sh --help                     ║
+╚══════════════════════════════════════════════════════════════════╝
+BANNER
+echo -e "${RESET}"
+fi
+
+PROJECT_DIR="$(abspath "$PROJECT_DIR")"
+build_find_cmd
+say "${WHITE}Project:${RESET}  ${CYAN}$PROJECT_DIR${RESET}"
+say "${WHITE}Started:${RESET}  ${GRAY}$(safe_date)${RESET}"
+
+# Count files with robust find
+TOTAL_FILES=$( ( set +o pipefail; "${FIND_CMD[@]}" 2>/dev/null || true ) | safe_count_files )
+TOTAL_FILES=$(( TOTAL_FILES + 0 ))
+say "${WHITE}Files:${RESET}    ${CYAN}$TOTAL_FILES source files (${INCLUDE_EXT})${RESET}"
+
+begin_scan_section
+
+if [[ -n "$PROTO_BASELINE" ]]; then
+  say "${WHITE}Baseline:${RESET} ${CYAN}$PROTO_BASELINE${RESET}"
+fi
+
+HAS_ANALYZER=1
+run_proto_analyzer || HAS_ANALYZER=0
+trap '[[ -n "$PROTO_FINDINGS_FILE" ]] && rm -f "$PROTO_FINDINGS_FILE"' EXIT
+if [[ "$HAS_ANALYZER" -eq 0 ]]; then
+  say "${YELLOW}${WARN} python3 not found - .proto contract analysis disabled${RESET}"
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 1: FIELD NUMBER HYGIENE
+# ═══════════════════════════════════════════════════════════════════════════
+if run_category 1; then
+print_header "1. FIELD NUMBER HYGIENE"
+print_category "Detects: fields reusing reserved numbers/names, numbering gaps without reserved" \
+  "Field numbers are the wire contract; a deleted number must stay reserved forever."
+
+print_subheader "Reuse of reserved (removed) field numbers"
+if [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  report_proto_rules "No field reuses a reserved number or name" proto.field.reserved-reuse
+else
+  print_finding "info" 0 "python3 not available" "Install python3 to enable .proto field checks"
+fi
+
+print_subheader "Numbering gaps without reserved declarations"
+if [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  report_proto_rules "Every field-number gap is reserved" proto.field.gap-not-reserved
+fi
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 2: BASELINE COMPATIBILITY
+# ═══════════════════════════════════════════════════════════════════════════
+if run_category 2; then
+print_header "2. BASELINE COMPATIBILITY"
+print_category "Detects: removed fields without reserved, reused numbers, type/cardinality changes, removed RPCs" \
+  "Compares the tree against a baseline descriptor set (UBS_PROTO_BASELINE / --baseline)."
+
+print_subheader "Breaking changes versus baseline"
+if [[ -z "$PROTO_BASELINE" ]]; then
+  say "  ${GRAY}${INFO} No baseline configured; set UBS_PROTO_BASELINE to a descriptor set (protoc --descriptor_set_out / buf build -o) or a directory of .proto files${RESET}"
+elif [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  baseline_error=$(awk -F'\t' '$1=="__ERROR__"{print $4; exit}' "$PROTO_FINDINGS_FILE" 2>/dev/null)
+  if [[ -n "$baseline_error" ]]; then
+    print_finding "info" 0 "Baseline unusable: $baseline_error" "$PROTO_BASELINE"
+  else
+    report_proto_rules "No breaking changes versus $PROTO_BASELINE" \
+      proto.baseline.field-number-reused proto.baseline.field-removed-not-reserved proto.baseline.field-type-changed \
+      proto.baseline.definition-removed proto.baseline.rpc-signature-changed proto.baseline.field-renamed
+  fi
+else
+  print_finding "info" 0 "python3 not available" "Install python3 to enable baseline comparison"
+fi
+fi
+
+end_scan_section
+
+# ═══════════════════════════════════════════════════════════════════════════
+# FINAL SUMMARY
+# ═══════════════════════════════════════════════════════════════════════════
+
+EXIT_CODE=0
+if [ "$CRITICAL_COUNT" -gt 0 ]; then EXIT_CODE=1; fi
+if [ "$FAIL_ON_WARNING" -eq 1 ] && [ $((CRITICAL_COUNT + WARNING_COUNT)) -gt 0 ]; then EXIT_CODE=1; fi
+
+if [[ "$FORMAT" == "json" ]]; then
+  emit_json_summary
+  exit "$EXIT_CODE"
+fi
+if [[ "$FORMAT" == "sarif" ]]; then
+  emit_sarif
+  exit "$EXIT_CODE"
+fi
+
+echo ""
+say "${BOLD}${WHITE}═══════════════════════════════════════════════════════════════════════════${RESET}"
+say "${BOLD}${CYAN}                    ${SHIELD} SCAN COMPLETE ${SHIELD}                                  ${RESET}"
+say "${BOLD}${WHITE}═══════════════════════════════════════════════════════════════════════════${RESET}"
+echo ""
+
+say "${WHITE}${BOLD}Summary Statistics:${RESET}"
+say "  ${WHITE}Files scanned:${RESET}    ${CYAN}$TOTAL_FILES${RESET}"
+say "  ${RED}${BOLD}Critical issues:${RESET}  ${RED}$CRITICAL_COUNT${RESET}"
+say "  ${YELLOW}Warning issues:${RESET}   ${YELLOW}$WARNING_COUNT${RESET}"
+say "  ${BLUE}Info items:${RESET}       ${BLUE}$INFO_COUNT${RESET}"
+echo ""
+
+if [ "$CRITICAL_COUNT" -eq 0 ] && [ "$WARNING_COUNT" -eq 0 ]; then
+  say "  ${GREEN}${BOLD}${SPARKLE} EXCELLENT! No critical or warning issues found ${SPARKLE}${RESET}"
+fi
+
+echo ""
+say "${DIM}Scan completed at: $(safe_date)${RESET}"
+
+if [[ -n "$OUTPUT_FILE" ]]; then
+  say "${GREEN}${CHECK} Full report saved to: ${CYAN}$OUTPUT_FILE${RESET}"
+fi
+if [[ -n "$SUMMARY_JSON" ]]; then
+  mkdir -p "$(dirname "$SUMMARY_JSON")" 2>/dev/null || true
+  printf '{"timestamp":"%s","files":%s,"critical":%s,"warning":%s,"info":%s}\n' \
+     "$(safe_date)" "$TOTAL_FILES" "$CRITICAL_COUNT" "$WARNING_COUNT" "$INFO_COUNT" >"$SUMMARY_JSON"
+fi
+
+echo ""
+say "${DIM}Add to pre-commit: ./ubs --ci --fail-on-warning --only=proto . > proto-bug-scan-report.txt${RESET}"
+echo ""
+
+exit "$EXIT_CODE"
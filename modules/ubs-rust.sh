@@ -266,7 +266,8 @@ json_escape() {
   s="${s//\\/\\\\}"
   s="${s//"/\\"}"
   s="${s//$'	'/\\t}"
-  s="${s//$''/\\r}"
+  s="${s//$'
+'/\\r}"
   s="${s//$'\n'/\\n}"
   printf '%s' "$s"
 }
@@ -1057,6 +1058,11 @@ patterns = {
     "fs": re.compile(r"\b(?:std::)?fs::(?:read|read_to_string|write|rename|copy|remove_file)\s*\("),
     "block_on": re.compile(r"\b(?:futures::executor::block_on|tokio::runtime::Runtime::block_on)\s*\("),
     "thread_spawn": re.compile(r"\b(?:std::)?thread::spawn\s*\("),
+    "blocking_io": re.compile(
+        r"\b(?:reqwest::blocking::[A-Za-z_]+|ureq::(?:get|post|put|delete|request|agent)\s*\("
+        r"|std::net::(?:TcpStream::connect|TcpListener::bind|UdpSocket::bind)\s*\("
+        r"|std::process::Command::new\s*\(|std::io::stdin\s*\()"
+    ),
 }
 
 pattern = patterns[mode]
@@ -1613,6 +1619,297 @@ collect_samples_drop_panic() {
   printf ']'
 }
 
+rust_hygiene_matches() {
+  local mode="$1"
+  [[ "$have_python3" -eq 1 ]] || return 1
+  python3 - "$PROJECT_DIR" "$mode" <<'PY'
+import re
+import sys
+from pathlib import Path
+
+root = Path(sys.argv[1])
+mode = sys.argv[2]
+
+
+def rust_files(path: Path):
+    if path.is_file():
+        if path.suffix == ".rs":
+            yield path
+        return
+    skip_dirs = {".git", "target", ".cargo", "node_modules"}
+    for child in path.rglob("*.rs"):
+        if skip_dirs.intersection(child.parts):
+            continue
+        yield child
+
+
+def mask_range(chars, start, end):
+    for pos in range(start, min(end, len(chars))):
+        if chars[pos] != "\n":
+            chars[pos] = " "
+
+
+def mask_comments_and_strings(text: str) -> str:
+    chars = list(text)
+    i = 0
+    n = len(chars)
+    state = "code"
+    while i < n:
+        ch = chars[i]
+        nxt = chars[i + 1] if i + 1 < n else ""
+        if state == "code":
+            if ch == "/" and nxt == "/":
+                start = i
+                i += 2
+                while i < n and chars[i] != "\n":
+                    i += 1
+                mask_range(chars, start, i)
+                continue
+            if ch == "/" and nxt == "*":
+                chars[i] = chars[i + 1] = " "
+                i += 2
+                state = "block"
+                continue
+            if ch == "r":
+                j = i + 1
+                while j < n and chars[j] == "#":
+                    j += 1
+                if j < n and chars[j] == '"':
+                    hashes = j - i - 1
+                    close = '"' + ("#" * hashes)
+                    end = text.find(close, j + 1)
+                    if end == -1:
+                        end = n - 1
+                    else:
+                        end += len(close)
+                    mask_range(chars, i, end)
+                    i = end
+                    continue
+            if ch == '"':
+                chars[i] = " "
+                i += 1
+                state = "string"
+                continue
+        elif state == "block":
+            if ch == "*" and nxt == "/":
+                chars[i] = chars[i + 1] = " "
+                i += 2
+                state = "code"
+                continue
+            if ch != "\n":
+                chars[i] = " "
+        elif state == "string":
+            if ch == "\\":
+                chars[i] = " "
+                if i + 1 < n and chars[i + 1] != "\n":
+                    chars[i + 1] = " "
+                    i += 2
+                    continue
+            if ch == '"':
+                chars[i] = " "
+                i += 1
+                state = "code"
+                continue
+            if ch != "\n":
+                chars[i] = " "
+        i += 1
+    return "".join(chars)
+
+
+def find_matching_brace(text: str, open_index: int) -> int:
+    depth = 0
+    for idx in range(open_index, len(text)):
+        ch = text[idx]
+        if ch == "{":
+            depth += 1
+        elif ch == "}":
+            depth -= 1
+            if depth == 0:
+                return idx
+    return -1
+
+
+def line_number(text: str, offset: int) -> int:
+    return text.count("\n", 0, offset) + 1
+
+
+TEST_DIRS = {"tests", "benches"}
+TEST_ATTR = re.compile(
+    r"#\s*\[\s*(?:cfg\s*\(\s*test\s*\)|(?:[A-Za-z_][A-Za-z0-9_]*::)*test\b[^\]]*|bench)\s*\]"
+)
+INNER_CFG_TEST = re.compile(r"#!\s*\[\s*cfg\s*\(\s*test\s*\)\s*\]")
+UNWRAP = re.compile(r"\.(?:unwrap|expect)\s*\(")
+UNSAFE = re.compile(r"\bunsafe\s*\{|\bunsafe\s+impl\b")
+FORGET = re.compile(r"\b(?:std::)?mem::forget\s*\(")
+SAFETY_NOTE = re.compile(r"\b(?:SAFETY|Safety)\s*:|#\s*Safety\b")
+GUARD_CALL = re.compile(
+    r"\.(?:lock|try_lock|read|try_read|write|try_write|borrow|borrow_mut|enter|"
+    r"lock_owned|read_owned|write_owned|upgradable_read)\s*\(\s*\)"
+)
+GUARD_TYPE = re.compile(r"\b(?:\w*Guard|Ref|RefMut|Entered)\b")
+
+
+def is_test_file(path: Path) -> bool:
+    try:
+        rel_parts = path.relative_to(root).parts
+    except ValueError:
+        rel_parts = path.parts
+    if TEST_DIRS.intersection(rel_parts[:-1]):
+        return True
+    return path.name == "tests.rs" or path.name.endswith("_test.rs") or path.name.endswith("_tests.rs")
+
+
+def test_ranges(masked: str):
+    ranges = []
+    for attr in TEST_ATTR.finditer(masked):
+        brace = masked.find("{", attr.end())
+        semi = masked.find(";", attr.end())
+        if brace < 0 or (0 <= semi < brace):
+            continue
+        close = find_matching_brace(masked, brace)
+        if close < 0:
+            continue
+        ranges.append((attr.start(), close))
+    return ranges
+
+
+def in_ranges(offset: int, ranges) -> bool:
+    return any(start <= offset <= end for start, end in ranges)
+
+
+def has_safety_comment(lines, line: int) -> bool:
+    if re.search(r"//.*" + SAFETY_NOTE.pattern, lines[line - 1]):
+        return True
+    idx = line - 2
+    while idx >= 0:
+        stripped = lines[idx].strip()
+        if not stripped:
+            break
+        if stripped.startswith(("//", "/*", "*", "#[")):
+            if SAFETY_NOTE.search(stripped):
+                return True
+            idx -= 1
+            continue
+        break
+    return False
+
+
+def call_argument(masked: str, open_index: int) -> str:
+    depth = 0
+    for idx in range(open_index, len(masked)):
+        ch = masked[idx]
+        if ch == "(":
+            depth += 1
+        elif ch == ")":
+            depth -= 1
+            if depth == 0:
+                return masked[open_index + 1:idx].strip()
+    return ""
+
+
+def holds_guard(masked: str, offset: int, arg: str) -> bool:
+    if GUARD_CALL.search(arg):
+        return True
+    if not re.fullmatch(r"[A-Za-z_][A-Za-z0-9_]*", arg):
+        return False
+    if "guard" in arg.lower():
+        return True
+    binding = re.compile(
+        rf"\blet\s+(?:mut\s+)?{re.escape(arg)}\s*(?::\s*([^=;]+))?=\s*([^;]+);"
+    )
+    last = None
+    for found in binding.finditer(masked, 0, offset):
+        last = found
+    if last is None:
+        return False
+    annotation, value = last.group(1) or "", last.group(2)
+    return bool(GUARD_CALL.search(value) or GUARD_TYPE.search(annotation))
+
+
+seen = set()
+
+for path in rust_files(root):
+    if mode == "unwrap_prod" and is_test_file(path):
+        continue
+    try:
+        text = path.read_text(encoding="utf-8", errors="replace")
+    except OSError:
+        continue
+    masked = mask_comments_and_strings(text)
+    if mode == "unwrap_prod" and INNER_CFG_TEST.search(masked):
+        continue
+    lines = text.splitlines()
+    hits = []
+    if mode == "unwrap_prod":
+        ranges = test_ranges(masked)
+        hits = [m.start() for m in UNWRAP.finditer(masked) if not in_ranges(m.start(), ranges)]
+    elif mode == "unsafe_no_safety":
+        for m in UNSAFE.finditer(masked):
+            line = line_number(masked, m.start())
+            if 0 < line <= len(lines) and not has_safety_comment(lines, line):
+                hits.append(m.start())
+    elif mode == "forget_guard":
+        for m in FORGET.finditer(masked):
+            arg = call_argument(masked, m.end() - 1)
+            if holds_guard(masked, m.start(), arg):
+                hits.append(m.start())
+    for offset in hits:
+        line = line_number(masked, offset)
+        key = (str(path), line)
+        if key in seen:
+            continue
+        seen.add(key)
+        code = lines[line - 1].strip() if 0 < line <= len(lines) else ""
+        prev = lines[line - 2] if 1 < line <= len(lines) + 1 else ""
+        if "ubs:ignore" in code or "ubs:ignore" in prev:
+            continue
+        print(f"{path}:{line}:{code}")
+PY
+}
+
+count_hygiene_matches() {
+  local mode="$1"
+  if [[ "$have_python3" -eq 1 ]]; then
+    rust_hygiene_matches "$mode" | count_lines || true
+  else
+    return 1
+  fi
+}
+
+show_hygiene_examples() {
+  local mode="$1"
+  local limit="${2:-$DETAIL_LIMIT}"
+  local printed=0
+  [[ "$have_python3" -eq 1 ]] || return 1
+  while IFS= read -r rawline; do
+    [[ -z "$rawline" ]] && continue
+    parse_grep_line "$rawline" || continue
+    print_code_sample "$PARSED_FILE" "$PARSED_LINE" "$PARSED_CODE"
+    printed=$((printed + 1))
+    [[ $printed -ge $limit || $printed -ge $MAX_DETAILED ]] && break
+  done < <(rust_hygiene_matches "$mode" | head -n "$limit")
+  [[ "$printed" -gt 0 ]]
+}
+
+collect_samples_hygiene() {
+  local mode="$1"
+  local limit="${2:-$DETAIL_LIMIT}"
+  if [[ "$have_python3" -ne 1 ]]; then
+    printf '[]'
+    return
+  fi
+  mapfile -t lines < <(rust_hygiene_matches "$mode" | head -n "$limit")
+  printf '['
+  local i=0
+  local line
+  for line in "${lines[@]}"; do
+    [[ $i -gt 0 ]] && printf ','
+    printf '"%s"' "$(printf '%s' "$line" | json_escape)"
+    i=$((i + 1))
+  done
+  printf ']'
+}
+
 rust_path_traversal_matches() {
   [[ "$have_python3" -eq 1 ]] || return 1
   python3 - "$PROJECT_DIR" <<'PY'
@@ -7566,7 +7863,7 @@ begin_scan_section
 # ═══════════════════════════════════════════════════════════════════════════
 if category_enabled 1; then
 print_header "1. OWNERSHIP & ERROR HANDLING MACROS"
-print_category "Detects: unwrap/expect, panic/unreachable/todo/unimplemented, dbg/println" \
+print_category "Detects: unwrap/expect outside tests, panic/unreachable/todo/unimplemented, dbg/println" \
   "Panic-prone and debug macros frequently leak into production and cause crashes"
 
 print_subheader "unwrap()/expect() usage"
@@ -7574,15 +7871,27 @@ print_subheader "unwrap()/expect() usage"
 unwrap_patterns=('$X.unwrap()')
 # shellcheck disable=SC2016
 expect_patterns=('$X.expect($MSG)')
-u_total=$(count_ast_or_rg "\.unwrap\(" "${unwrap_patterns[@]}")
-e_total=$(count_ast_or_rg "\.expect\(" "${expect_patterns[@]}")
-ue_total=$((u_total + e_total))
-if [ "$ue_total" -gt 0 ]; then
-  print_finding "warning" "$ue_total" "Potential panics via unwrap/expect" "Prefer \`?\` or match to propagate/handle errors"
-  show_ast_pattern_examples 5 "${unwrap_patterns[@]}" "${expect_patterns[@]}" || show_detailed_finding "\.(unwrap|expect)\(" 5
-  add_finding "warning" "$ue_total" "Potential panics via unwrap/expect" "Prefer \`?\` or match to propagate/handle errors" "${CATEGORY_NAME[1]}" "$(collect_samples_ast_or_rg "\.(unwrap|expect)\(" 5 "${unwrap_patterns[@]}" "${expect_patterns[@]}")"
+if [[ "$have_python3" -eq 1 ]]; then
+  # Test-aware: skips tests/ and benches/, #[cfg(test)] modules, and #[test] fns
+  ue_total=$(count_hygiene_matches "unwrap_prod")
+  if [ "$ue_total" -gt 0 ]; then
+    print_finding "warning" "$ue_total" "Potential panics via unwrap/expect in non-test code" "Prefer \`?\` or match to propagate/handle errors"
+    show_hygiene_examples "unwrap_prod" 5 || show_detailed_finding "\.(unwrap|expect)\(" 5
+    add_finding "warning" "$ue_total" "Potential panics via unwrap/expect in non-test code" "Prefer \`?\` or match to propagate/handle errors" "${CATEGORY_NAME[1]}" "$(collect_samples_hygiene "unwrap_prod" 5)"
+  else
+    print_finding "good" "No unwrap/expect outside test code"
+  fi
 else
-  print_finding "good" "No unwrap/expect detected"
+  u_total=$(count_ast_or_rg "\.unwrap\(" "${unwrap_patterns[@]}")
+  e_total=$(count_ast_or_rg "\.expect\(" "${expect_patterns[@]}")
+  ue_total=$((u_total + e_total))
+  if [ "$ue_total" -gt 0 ]; then
+    print_finding "warning" "$ue_total" "Potential panics via unwrap/expect" "Prefer \`?\` or match to propagate/handle errors"
+    show_ast_pattern_examples 5 "${unwrap_patterns[@]}" "${expect_patterns[@]}" || show_detailed_finding "\.(unwrap|expect)\(" 5
+    add_finding "warning" "$ue_total" "Potential panics via unwrap/expect" "Prefer \`?\` or match to propagate/handle errors" "${CATEGORY_NAME[1]}" "$(collect_samples_ast_or_rg "\.(unwrap|expect)\(" 5 "${unwrap_patterns[@]}" "${expect_patterns[@]}")"
+  else
+    print_finding "good" "No unwrap/expect detected"
+  fi
 fi
 
 print_subheader "panic!/unreachable!/todo!/unimplemented!"
@@ -7650,7 +7959,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if category_enabled 2; then
 print_header "2. UNSAFE & MEMORY OPERATIONS"
-print_category "Detects: unsafe blocks, transmute/uninitialized/zeroed/forget, raw ffi hazards" \
+print_category "Detects: unsafe blocks (and missing SAFETY comments), transmute/uninitialized/zeroed/forget, guard leaks, raw ffi hazards" \
   "These patterns may introduce UB, memory leaks, or hard-to-debug crashes"
 
 print_subheader "unsafe { ... } blocks"
@@ -7664,6 +7973,18 @@ else
   print_finding "good" "No unsafe blocks detected"
 fi
 
+print_subheader "unsafe without // SAFETY: justification"
+if [[ "$have_python3" -eq 1 ]]; then
+  unsafe_undoc=$(count_hygiene_matches "unsafe_no_safety")
+  if [ "$unsafe_undoc" -gt 0 ]; then
+    print_finding "warning" "$unsafe_undoc" "unsafe block/impl without SAFETY comment" "Document the invariant that makes it sound in a // SAFETY: comment directly above"
+    show_hygiene_examples "unsafe_no_safety" 3 || true
+    add_finding "warning" "$unsafe_undoc" "unsafe block/impl without SAFETY comment" "Document the invariant that makes it sound in a // SAFETY: comment directly above" "${CATEGORY_NAME[2]}" "$(collect_samples_hygiene "unsafe_no_safety" 3)"
+  elif [ "$unsafe_count" -gt 0 ]; then
+    print_finding "good" "Every unsafe block carries a SAFETY comment"
+  fi
+fi
+
 print_subheader "transmute, uninitialized, zeroed, assume_init, forget"
 # shellcheck disable=SC2016
 transmute_count=$(count_ast_or_rg 'transmute\(' 'std::mem::transmute($X)' 'mem::transmute($X)' 'transmute($X)')
@@ -7710,6 +8031,14 @@ if [ "$forget_count" -gt 0 ]; then
   # shellcheck disable=SC2016
   add_finding "warning" "$forget_count" "mem::forget leaks memory" "" "${CATEGORY_NAME[2]}" "$(collect_samples_ast_or_rg "mem::forget\(" 3 'std::mem::forget($X)' 'mem::forget($X)')"
 fi
+if [[ "$have_python3" -eq 1 && "$forget_count" -gt 0 ]]; then
+  forget_guard=$(count_hygiene_matches "forget_guard")
+  if [ "$forget_guard" -gt 0 ]; then
+    print_finding "critical" "$forget_guard" "mem::forget on a lock/borrow guard" "The guard never drops, so the lock or borrow stays held forever; drop it or restructure the scope"
+    show_hygiene_examples "forget_guard" 3 || true
+    add_finding "critical" "$forget_guard" "mem::forget on a lock/borrow guard" "The guard never drops, so the lock or borrow stays held forever; drop it or restructure the scope" "${CATEGORY_NAME[2]}" "$(collect_samples_hygiene "forget_guard" 3)"
+  fi
+fi
 
 print_subheader "CStr::from_bytes_with_nul_unchecked"
 # shellcheck disable=SC2016
@@ -7763,7 +8092,7 @@ fi
 # ═══════════════════════════════════════════════════════════════════════════
 if category_enabled 3; then
 print_header "3. CONCURRENCY & ASYNC PITFALLS"
-print_category "Detects: Arc<Mutex>, Rc<RefCell>, blocking ops in async, await-in-loop, spawn misuse" \
+print_category "Detects: Arc<Mutex>, Rc<RefCell>, blocking fs/net/process ops in async, await-in-loop, spawn misuse" \
   "Concurrency misuse leads to deadlocks, head-of-line blocking, and performance issues"
 
 print_subheader "Arc<Mutex<..>> / Rc<RefCell<..>> / RwLock"
@@ -7803,6 +8132,16 @@ if [ "$fs_async" -gt 0 ]; then
   add_finding "info" "$fs_async" "Blocking std::fs in async code" "" "${CATEGORY_NAME[3]}" "$(collect_samples_async_context "fs" 3)"
 fi
 
+print_subheader "Blocking network/process I/O inside async"
+if [[ "$have_python3" -eq 1 ]]; then
+  io_async=$(count_async_context_matches "blocking_io")
+  if [ "$io_async" -gt 0 ]; then
+    print_finding "warning" "$io_async" "Blocking network/process I/O in async fn" "Use tokio::net / tokio::process / async clients or wrap in spawn_blocking"
+    show_async_context_examples "blocking_io" 3 || true
+    add_finding "warning" "$io_async" "Blocking network/process I/O in async fn" "Use tokio::net / tokio::process / async clients or wrap in spawn_blocking" "${CATEGORY_NAME[3]}" "$(collect_samples_async_context "blocking_io" 3)"
+  fi
+fi
+
 print_subheader "block_on within async context"
 if [[ "$have_python3" -eq 1 ]]; then
   block_on=$(count_async_context_matches "block_on")
@@ -0,0 +1,697 @@
+#!/usr/bin/env bash
+# ═══════════════════════════════════════════════════════════════════════════
+# SQL ULTIMATE BUG SCANNER v1.0.0 (Bash) - Schema & Migration Analysis
+# ═══════════════════════════════════════════════════════════════════════════
+# Static analysis for plain `.sql` files (migrations, seeds, ad-hoc scripts)
+# using a statement-aware python3 pass:
+#   • comments, string literals and dollar-quoted bodies are masked first
+#   • statements are split on `;` and matched as whole units, not lines
+#   • goose / sql-migrate / dbmate "down" sections and *.down.sql files are
+#     treated as rollbacks where destructive DDL is expected
+#
+# Focus:
+#   • destructive DDL in migrations  • unbounded UPDATE / DELETE
+#   • Postgres online indexing       • CI gating for schema changes
+#
+# Supports:
+#   --format text|json|sarif (json/sarif => pure machine output)
+#   --fail-on-warning, --skip, --only, --jobs, --include-ext, --exclude
+#   --ci, --no-color, --summary-json
+# ═══════════════════════════════════════════════════════════════════════════
+
+if [ "${BASH_VERSINFO[0]:-0}" -lt 4 ]; then
+  echo "ERROR: ubs-sql.sh requires bash >= 4.0 (you have ${BASH_VERSION:-unknown})." >&2
+  echo "       On macOS: 'brew install bash' and re-run via /opt/homebrew/bin/bash." >&2
+  exit 2
+fi
+
+set -Eeuo pipefail
+umask 022
+shopt -s lastpipe
+shopt -s extglob
+
+SCRIPT_DIR="$(cd -- "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+
+# ────────────────────────────────────────────────────────────────────────────
+# Globals & defaults
+# ────────────────────────────────────────────────────────────────────────────
+
+VERBOSE=0
+PROJECT_DIR="."
+OUTPUT_FILE=""
+FORMAT="text"          # text|json|sarif
+CI_MODE=0
+FAIL_ON_WARNING=0
+INCLUDE_EXT="sql"
+QUIET=0
+NO_COLOR_FLAG=0
+EXTRA_EXCLUDES=""
+SKIP_CATEGORIES=""
+ONLY_CATEGORIES=""
+DETAIL_LIMIT=3
+MAX_DETAILED=250
+JOBS="${JOBS:-0}"
+
+# Comma-separated table names known to be large in production. CREATE INDEX
+# without CONCURRENTLY on one of these is escalated from warning to critical.
+SQL_LARGE_TABLES="${UBS_SQL_LARGE_TABLES:-}"
+
+SUMMARY_JSON=""
+
+CHECK="✓"; CROSS="✗"; WARN="⚠"; INFO="ℹ"; ARROW="→"; BULLET="•"; FIRE="🔥"; SPARKLE="✨"; SHIELD="🛡"
+
+# Color handling
+USE_COLOR=1
+if [[ -n "${NO_COLOR:-}" || ! -t 1 ]]; then USE_COLOR=0; fi
+if [[ "$USE_COLOR" -eq 1 ]]; then
+  RED='\033[0;31m'; GREEN='\033[0;32m'; YELLOW='\033[1;33m'; BLUE='\033[0;34m'
+  MAGENTA='\033[0;35m'; CYAN='\033[0;36m'; WHITE='\033[1;37m'; GRAY='\033[0;90m'
+  BOLD='\033[1m'; DIM='\033[2m'; RESET='\033[0m'
+else
+  RED=''; GREEN=''; YELLOW=''; BLUE=''; MAGENTA=''; CYAN=''; WHITE=''; GRAY=''
+  BOLD=''; DIM=''; RESET=''
+fi
+
+# ────────────────────────────────────────────────────────────────────────────
+# Error handling
+# ────────────────────────────────────────────────────────────────────────────
+
+on_err() {
+  local ec=$?; local cmd=${BASH_COMMAND}; local line=${BASH_LINENO[0]}; local src=${BASH_SOURCE[1]:-${BASH_SOURCE[0]}}
+  if [[ "${FORMAT:-text}" == "json" || "${FORMAT:-text}" == "sarif" ]]; then
+    echo "{\"error\":{\"exit\":$ec,\"file\":\"$src\",\"line\":$line,\"cmd\":\"${cmd//\"/\\\"}\"}}" >&2; exit "$ec"
+  fi
+  echo -e "\n${RED}${BOLD}Unexpected error (exit $ec)${RESET} ${DIM}at ${src}:${line}${RESET}\n${DIM}Last command:${RESET} ${WHITE}$cmd${RESET}" >&2
+  exit "$ec"
+}
+trap on_err ERR
+
+print_usage() {
+  cat >&2 <<USAGE
+Usage: $(basename "$0") [options] [PROJECT_DIR] [OUTPUT_FILE]
+
+Options:
+  -v, --verbose            More code samples per finding (DETAIL=10)
+  --very-verbose           Max code samples (DETAIL=25)
+  -q, --quiet              Reduce non-essential output
+  --format=FMT             Output format: text|json|sarif (default: text)
+  --summary-json=FILE      Save brief summary counters JSON
+  --ci                     CI mode (no clear, stable timestamps)
+  --no-color               Force disable ANSI color
+  --include-ext=CSV        File extensions (default: $INCLUDE_EXT)
+  --exclude=GLOB[,..]      Additional glob(s)/dir(s) to exclude
+  --only=CSV               Only run these category numbers
+  --jobs=N                 Accepted for meta-runner compatibility
+  --skip=CSV               Skip categories by number (e.g. --skip=2,3)
+  --fail-on-warning        Exit non-zero on warnings or critical
+  --large-tables=CSV       Tables whose blocking index builds are critical
+  -h, --help               Show help
+Env:
+  JOBS, NO_COLOR, CI, UBS_METRICS_DIR, UBS_SQL_LARGE_TABLES
+Args:
+  PROJECT_DIR              Directory to scan (default: ".")
+  OUTPUT_FILE              File to save the report (optional)
+USAGE
+}
+
+# CLI parsing
+while [[ $# -gt 0 ]]; do
+  case "$1" in
+    -v|--verbose) VERBOSE=1; DETAIL_LIMIT=10; shift;;
+    --very-verbose) VERBOSE=2; DETAIL_LIMIT=25; shift;;
+    -q|--quiet)   VERBOSE=0; DETAIL_LIMIT=1; QUIET=1; shift;;
+    --format=*)   FORMAT="${1#*=}"; shift;;
+    --summary-json=*) SUMMARY_JSON="${1#*=}"; shift;;
+    --ci)         CI_MODE=1; shift;;
+    --no-color)   NO_COLOR_FLAG=1; shift;;
+    --include-ext=*) INCLUDE_EXT="${1#*=}"; shift;;
+    --exclude=*)  EXTRA_EXCLUDES="${1#*=}"; shift;;
+    --only=*)     ONLY_CATEGORIES="${1#*=}"; shift;;
+    --jobs=*)     JOBS="${1#*=}"; shift;;
+    --skip=*)     SKIP_CATEGORIES="${1#*=}"; shift;;
+    --fail-on-warning) FAIL_ON_WARNING=1; shift;;
+    --large-tables=*) SQL_LARGE_TABLES="${1#*=}"; shift;;
+    -h|--help)    print_usage; exit 0;;
+    *)
+      if [[ -z "$PROJECT_DIR" || "$PROJECT_DIR" == "." ]] && ! [[ "$1" =~ ^- ]]; then
+        PROJECT_DIR="$1"; shift
+      elif [[ -z "$OUTPUT_FILE" ]] && ! [[ "$1" =~ ^- ]]; then
+        if [[ -e "$1" && -s "$1" ]]; then
+          echo "error: refusing to use existing non-empty file '$1' as OUTPUT_FILE (would be overwritten)." >&2
+          echo "       To scan multiple paths, use the meta-runner 'ubs'. To save a report, pass a fresh (non-existing) path." >&2
+          exit 2
+        fi
+        OUTPUT_FILE="$1"; shift
+      else
+        echo "Unexpected argument: $1" >&2; exit 2
+      fi
+      ;;
+  esac
+done
+
+# CI auto-detect + color override
+if [[ -n "${CI:-}" ]]; then CI_MODE=1; fi
+if [[ "$NO_COLOR_FLAG" -eq 1 ]]; then
+  USE_COLOR=0
+  RED=''; GREEN=''; YELLOW=''; BLUE=''; MAGENTA=''; CYAN=''; WHITE=''; GRAY=''
+  BOLD=''; DIM=''; RESET=''
+fi
+
+# Redirect output early to capture everything (honors machine formats too)
+if [[ -n "${OUTPUT_FILE}" ]]; then
+  if command -v tee >/dev/null 2>&1; then
+    exec > >(tee "${OUTPUT_FILE}") 2>&1
+  else
+    exec > "${OUTPUT_FILE}" 2>&1
+  fi
+fi
+
+DATE_FMT='%Y-%m-%d %H:%M:%S'
+safe_date() {
+  if [[ "$CI_MODE" -eq 1 ]]; then
+    command date -u '+%Y-%m-%dT%H:%M:%SZ' 2>/dev/null || command date '+%Y-%m-%dT%H:%M:%SZ'
+  else
+    command date "+$DATE_FMT"
+  fi
+}
+is_machine_format(){ [[ "$FORMAT" == "json" || "$FORMAT" == "sarif" ]]; }
+
+# If machine format: silence all user-facing text immediately.
+if is_machine_format; then
+  QUIET=1
+  USE_COLOR=0
+fi
+
+# ────────────────────────────────────────────────────────────────────────────
+# Global Counters
+# ────────────────────────────────────────────────────────────────────────────
+CRITICAL_COUNT=0
+WARNING_COUNT=0
+INFO_COUNT=0
+TOTAL_FILES=0
+
+# ────────────────────────────────────────────────────────────────────────────
+# Utilities
+# ────────────────────────────────────────────────────────────────────────────
+maybe_clear() { if [[ -t 1 && "$CI_MODE" -eq 0 ]] && ! is_machine_format; then clear || true; fi; }
+say() { [[ "$QUIET" -eq 1 ]] && return 0; echo -e "$*"; }
+
+json_escape() {
+  local s="${1-}"
+  s=${s//\\/\\\\}
+  s=${s//\"/\\\"}
+  s=${s//$'\n'/\\n}
+  s=${s//$'\r'/\\r}
+  s=${s//$'\t'/\\t}
+  printf '%s' "$s"
+}
+
+emit_json_summary() {
+  local ts json
+  ts="$(safe_date)"
+  json="$(printf '{"project":"%s","files":%s,"critical":%s,"warning":%s,"info":%s,"timestamp":"%s","format":"json"}\n' \
+    "$(json_escape "$PROJECT_DIR")" "$TOTAL_FILES" "$CRITICAL_COUNT" "$WARNING_COUNT" "$INFO_COUNT" "$(json_escape "$ts")")"
+  printf '%s' "$json"
+  if [[ -n "$SUMMARY_JSON" ]]; then
+    mkdir -p "$(dirname "$SUMMARY_JSON")" 2>/dev/null || true
+    printf '%s' "$json" >"$SUMMARY_JSON"
+  fi
+}
+
+emit_sarif() {
+  printf '%s\n' '{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"ubs-sql"}},"results":[]}]}'
+}
+print_header() { say "\n${CYAN}${BOLD}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${RESET}"; say "${WHITE}${BOLD}$1${RESET}"; say "${CYAN}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${RESET}"; }
+print_category() { say "\n${MAGENTA}${BOLD}▓▓▓ $1${RESET}"; say "${DIM}$2${RESET}"; }
+print_subheader() { say "\n${YELLOW}${BOLD}$BULLET $1${RESET}"; }
+print_finding() {
+  local severity=$1
+  case $severity in
+    good) local title=$2; say "  ${GREEN}${CHECK} OK${RESET} ${DIM}$title${RESET}" ;;
+    *)
+      local raw_count=$2; local title=$3; local description="${4:-}"
+      local count; count=$(printf '%s\n' "$raw_count" | awk 'END{print $0+0}')
+      case $severity in
+        critical) CRITICAL_COUNT=$((CRITICAL_COUNT + count)); say "  ${RED}${BOLD}${FIRE} CRITICAL${RESET} ${WHITE}($count found)${RESET}"; say "    ${RED}${BOLD}$title${RESET}"; [ -n "$description" ] && say "    ${DIM}$description${RESET}" || true ;;
+        warning)  WARNING_COUNT=$((WARNING_COUNT + count)); say "  ${YELLOW}${WARN} Warning${RESET} ${WHITE}($count found)${RESET}"; say "    ${YELLOW}$title${RESET}"; [ -n "$description" ] && say "    ${DIM}$description${RESET}" || true ;;
+        info)     INFO_COUNT=$((INFO_COUNT + count));      say "  ${BLUE}${INFO} Info${RESET} ${WHITE}($count found)${RESET}"; say "    ${BLUE}$title${RESET}"; [ -n "$description" ] && say "    ${DIM}$description${RESET}" || true ;;
+      esac
+      ;;
+  esac
+}
+print_code_sample() { local file=$1; local line=$2; local code=$3; say "${GRAY}      $file:$line${RESET}"; say "${WHITE}      $code${RESET}"; }
+
+begin_scan_section(){ set +o pipefail; set +e; trap - ERR; }
+end_scan_section(){ trap on_err ERR; set -e; set -o pipefail; }
+
+mktemp_file(){ mktemp 2>/dev/null || mktemp -t ubs-sql.XXXXXX; }
+
+# Path helpers & robust file discovery
+abspath() { perl -MCwd=abs_path -e 'print abs_path(shift)' -- "$1" 2>/dev/null || python3 - "$1" <<'PY'
+import os,sys; print(os.path.abspath(sys.argv[1]))
+PY
+}
+
+LC_ALL=C
+IFS=',' read -r -a _EXT_ARR <<<"$INCLUDE_EXT"
+EXCLUDE_DIRS=(.git .hg .svn .bzr node_modules vendor dist build target .venv venv .cache .idea .vscode .history tmp log)
+if [[ -n "$EXTRA_EXCLUDES" ]]; then IFS=',' read -r -a _X <<<"$EXTRA_EXCLUDES"; EXCLUDE_DIRS+=("${_X[@]}"); fi
+
+build_find_cmd() {
+  local -a prune=( )
+  for d in "${EXCLUDE_DIRS[@]}"; do prune+=( -name "$d" -o ); done
+  [[ ${#prune[@]} -gt 0 ]] && unset 'prune[${#prune[@]}-1]'
+  local -a names=( ); local first=1
+  for e in "${_EXT_ARR[@]}"; do if [[ $first -eq 1 ]]; then names+=( -name "*.$e" ); first=0; else names+=( -o -name "*.$e" ); fi; done
+  FIND_CMD=(find "$PROJECT_DIR" \( -type d \( "${prune[@]}" \) -prune \) -o \( -type f \( "${names[@]}" \) -print0 \))
+}
+safe_count_files(){ tr -cd '\0' | awk 'END{print (length>0?gsub(/\0/,"")+0:0)}'; }
+
+# Category gating (run if returns 0)
+run_category() {
+  local cat="$1"
+  if [[ -n "$ONLY_CATEGORIES" ]]; then
+    IFS=',' read -r -a arr <<<"$ONLY_CATEGORIES"
+    for s in "${arr[@]}"; do [[ "$s" == "$cat" ]] && return 0; done
+    return 1
+  fi
+  if [[ -z "$SKIP_CATEGORIES" ]]; then return 0; fi
+  IFS=',' read -r -a arr <<<"$SKIP_CATEGORIES"
+  for s in "${arr[@]}"; do [[ "$s" == "$cat" ]] && return 1; done
+  return 0
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Statement analyzer
+# ────────────────────────────────────────────────────────────────────────────
+# One python3 pass over every .sql file; emits `rule<TAB>path<TAB>line<TAB>code`
+# rows into SQL_FINDINGS_FILE. Each category then reports its own rule ids.
+
+SQL_RULE_IDS=(
+  sql.migration.destructive-unguarded
+  sql.dml.missing-where
+  sql.postgres.index-not-concurrent
+  sql.postgres.index-not-concurrent-large
+)
+
+declare -A SQL_SUMMARY=(
+  [sql.migration.destructive-unguarded]="Destructive DDL in migration without a guard"
+  [sql.dml.missing-where]="UPDATE/DELETE without WHERE clause"
+  [sql.postgres.index-not-concurrent]="CREATE INDEX without CONCURRENTLY on an existing table"
+  [sql.postgres.index-not-concurrent-large]="Blocking CREATE INDEX on a known large table"
+)
+
+declare -A SQL_REMEDIATION=(
+  [sql.migration.destructive-unguarded]="Use DROP ... IF EXISTS, move the drop into the down migration, or wrap it in a DO block that checks state first; TRUNCATE in a forward migration needs an explicit ubs:ignore with the reason."
+  [sql.dml.missing-where]="Add a WHERE clause (or an explicit WHERE true with a comment) so a whole-table rewrite is never accidental."
+  [sql.postgres.index-not-concurrent]="Use CREATE INDEX CONCURRENTLY in its own non-transactional migration so writes are not blocked while the index builds."
+  [sql.postgres.index-not-concurrent-large]="This table is listed in UBS_SQL_LARGE_TABLES/--large-tables; a plain CREATE INDEX holds a SHARE lock for the whole build. Use CREATE INDEX CONCURRENTLY."
+)
+
+declare -A SQL_SEVERITY=(
+  [sql.migration.destructive-unguarded]="critical"
+  [sql.dml.missing-where]="critical"
+  [sql.postgres.index-not-concurrent]="warning"
+  [sql.postgres.index-not-concurrent-large]="critical"
+)
+
+SQL_FINDINGS_FILE=""
+
+run_sql_analyzer() {
+  SQL_FINDINGS_FILE="$(mktemp_file)"
+  if ! command -v python3 >/dev/null 2>&1; then
+    return 1
+  fi
+  python3 - "$PROJECT_DIR" "$SQL_LARGE_TABLES" "${EXCLUDE_DIRS[*]}" "$INCLUDE_EXT" >"$SQL_FINDINGS_FILE" <<'PY' || true
+import re
+import sys
+from pathlib import Path
+
+ROOT = Path(sys.argv[1]).resolve()
+BASE_DIR = ROOT if ROOT.is_dir() else ROOT.parent
+LARGE_TABLES = {t.strip().strip('"').lower().split('.')[-1] for t in sys.argv[2].split(',') if t.strip()}
+SKIP_DIRS = set(sys.argv[3].split())
+EXTS = {'.' + e.strip().lstrip('.').lower() for e in sys.argv[4].split(',') if e.strip()}
+
+MIGRATION_DIR_RE = re.compile(r'^(?:migrations?|migrate|db_?migrations?|schema_?migrations?|flyway|liquibase|changesets?|alembic|sqitch)$', re.IGNORECASE)
+MIGRATION_NAME_RE = re.compile(r'^(?:V\d+(?:[._]\d+)*__|U\d+(?:[._]\d+)*__|R__|\d{3,}[_.-])', re.IGNORECASE)
+MIGRATION_MARKER_RE = re.compile(r'^\s*--\s*(?:\+goose\s+(?:Up|Down)|\+migrate\s+(?:Up|Down)|migrate:(?:up|down))\b', re.IGNORECASE | re.MULTILINE)
+DOWN_FILE_RE = re.compile(r'(?:^|[._-])down\.sql$|^U\d+(?:[._]\d+)*__', re.IGNORECASE)
+DOWN_MARKER_RE = re.compile(r'^\s*--\s*(?:\+goose\s+Down|\+migrate\s+Down|migrate:down)\b', re.IGNORECASE)
+UP_MARKER_RE = re.compile(r'^\s*--\s*(?:\+goose\s+Up|\+migrate\s+Up|migrate:up)\b', re.IGNORECASE)
+NON_POSTGRES_RE = re.compile(r'`|\bENGINE\s*=|\bAUTO_INCREMENT\b|\bAUTOINCREMENT\b|^\s*PRAGMA\b|\bIDENTITY\s*\(\s*\d+\s*,|\bNVARCHAR\b|^\s*GO\s*$', re.IGNORECASE | re.MULTILINE)
+
+IDENT = r'(?:"[^"]+"|[A-Za-z_][A-Za-z0-9_$]*)(?:\s*\.\s*(?:"[^"]+"|[A-Za-z_][A-Za-z0-9_$]*))*'
+CREATE_TABLE_RE = re.compile(rf'^CREATE\s+(?:(?:GLOBAL|LOCAL)\s+)?(?:(?:TEMP|TEMPORARY|UNLOGGED)\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?({IDENT})', re.IGNORECASE)
+DROP_RE = re.compile(rf'^DROP\s+(TABLE|SCHEMA|DATABASE|VIEW|MATERIALIZED\s+VIEW|SEQUENCE|TYPE)\s+(IF\s+EXISTS\s+)?({IDENT})', re.IGNORECASE)
+DROP_COLUMN_RE = re.compile(rf'^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?({IDENT})\s.*?\bDROP\s+(?:COLUMN\s+)?(?!CONSTRAINT\b|INDEX\b|DEFAULT\b|NOT\s+NULL\b|IDENTITY\b|EXPRESSION\b|PRIMARY\b|FOREIGN\b|KEY\b)(IF\s+EXISTS\s+)?({IDENT})', re.IGNORECASE | re.DOTALL)
+TRUNCATE_RE = re.compile(rf'^TRUNCATE\s+(?:TABLE\s+)?(?:ONLY\s+)?({IDENT})', re.IGNORECASE)
+UPDATE_RE = re.compile(rf'^UPDATE\s+(?:ONLY\s+)?({IDENT})\s', re.IGNORECASE)
+DELETE_RE = re.compile(rf'^DELETE\s+FROM\s+(?:ONLY\s+)?({IDENT})', re.IGNORECASE)
+WHERE_RE = re.compile(r'\bWHERE\b', re.IGNORECASE)
+CREATE_INDEX_RE = re.compile(rf'^CREATE\s+(?:UNIQUE\s+)?INDEX\s+(CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(?:{IDENT}\s+)?ON\s+(?:ONLY\s+)?({IDENT})', re.IGNORECASE)
+
+
+def should_skip(path: Path) -> bool:
+    try:
+        parts = path.relative_to(BASE_DIR).parts
+    except ValueError:
+        parts = path.parts
+    return any(part in SKIP_DIRS for part in parts[:-1])
+
+
+def iter_files(root: Path):
+    if root.is_file():
+        if root.suffix.lower() in EXTS:
+            yield root
+        return
+    for path in sorted(root.rglob('*')):
+        if path.is_file() and path.suffix.lower() in EXTS and not should_skip(path):
+            yield path
+
+
+def relpath(path):
+    try:
+        return str(path.relative_to(BASE_DIR))
+    except ValueError:
+        return str(path)
+
+
+def table_key(name):
+    last = re.split(r'\s*\.\s*', name.strip())[-1]
+    return last.strip('"').lower()
+
+
+def mask(text):
+    """Blank out comments, string literals and dollar-quoted bodies, keeping newlines."""
+    out = list(text)
+    i, n = 0, len(text)
+
+    def blank(start, end):
+        for k in range(start, min(end, n)):
+            if out[k] != '\n':
+                out[k] = ' '
+
+    while i < n:
+        ch = text[i]
+        if ch == '-' and text.startswith('--', i):
+            end = text.find('\n', i)
+            end = n if end < 0 else end
+            blank(i, end)
+            i = end
+        elif ch == '/' and text.startswith('/*', i):
+            end = text.find('*/', i + 2)
+            end = n if end < 0 else end + 2
+            blank(i, end)
+            i = end
+        elif ch == "'":
+            j = i + 1
+            while j < n:
+                if text[j] == "'":
+                    if j + 1 < n and text[j + 1] == "'":
+                        j += 2
+                        continue
+                    break
+                j += 1
+            blank(i + 1, j)
+            i = j + 1
+        elif ch == '$':
+            m = re.match(r'\$([A-Za-z_][A-Za-z0-9_]*)?\$', text[i:])
+            if not m:
+                i += 1
+                continue
+            tag = m.group(0)
+            end = text.find(tag, i + len(tag))
+            end = n if end < 0 else end + len(tag)
+            blank(i + len(tag), end - len(tag))
+            i = end
+        else:
+            i += 1
+    return ''.join(out)
+
+
+def down_lines(lines, whole_file_down):
+    flags = []
+    down = whole_file_down
+    for line in lines:
+        if DOWN_MARKER_RE.search(line):
+            down = True
+        elif UP_MARKER_RE.search(line):
+            down = False
+        flags.append(down)
+    return flags
+
+
+def has_ignore(lines, line_no):
+    idx = line_no - 1
+    return (0 <= idx < len(lines) and 'ubs:ignore' in lines[idx]) or (0 <= idx - 1 < len(lines) and 'ubs:ignore' in lines[idx - 1])
+
+
+def statements(masked):
+    start = 0
+    for m in re.finditer(r';', masked):
+        yield start, masked[start:m.end() - 1]
+        start = m.end()
+    if masked[start:].strip():
+        yield start, masked[start:]
+
+
+def is_migration(path, text):
+    rel_parts = Path(relpath(path)).parts
+    if any(MIGRATION_DIR_RE.match(part) for part in rel_parts[:-1]):
+        return True
+    if MIGRATION_NAME_RE.match(path.name):
+        return True
+    return bool(MIGRATION_MARKER_RE.search(text))
+
+
+def emit(rule, path, lines, line_no):
+    code = lines[line_no - 1].strip().replace('\t', ' ') if 0 < line_no <= len(lines) else ''
+    print(f"{rule}\t{relpath(path)}\t{line_no}\t{code}")
+
+
+def analyze(path):
+    try:
+        text = path.read_text(encoding='utf-8', errors='ignore')
+    except OSError:
+        return
+    lines = text.splitlines()
+    masked = mask(text)
+    migration = is_migration(path, text)
+    postgres = not NON_POSTGRES_RE.search(masked)
+    in_down = down_lines(lines, bool(DOWN_FILE_RE.search(path.name)))
+    created = set()
+    for offset, raw in statements(masked):
+        lead = len(raw) - len(raw.lstrip())
+        stmt = re.sub(r'\s+', ' ', raw).strip()
+        if not stmt:
+            continue
+        line_no = masked.count('\n', 0, offset + lead) + 1
+        if has_ignore(lines, line_no):
+            continue
+        down = in_down[line_no - 1] if line_no - 1 < len(in_down) else False
+
+        m = CREATE_TABLE_RE.match(stmt)
+        if m:
+            created.add(table_key(m.group(1)))
+            continue
+
+        if migration and not down:
+            m = DROP_RE.match(stmt)
+            if m and not m.group(2) and not (m.group(1).upper() == 'TABLE' and table_key(m.group(3)) in created):
+                emit('sql.migration.destructive-unguarded', path, lines, line_no)
+                continue
+            m = DROP_COLUMN_RE.match(stmt)
+            if m and not m.group(2) and table_key(m.group(1)) not in created:
+                emit('sql.migration.destructive-unguarded', path, lines, line_no)
+                continue
+            m = TRUNCATE_RE.match(stmt)
+            if m and table_key(m.group(1)) not in created:
+                emit('sql.migration.destructive-unguarded', path, lines, line_no)
+                continue
+
+        m = UPDATE_RE.match(stmt) or DELETE_RE.match(stmt)
+        if m and not WHERE_RE.search(stmt) and table_key(m.group(1)) not in created:
+            emit('sql.dml.missing-where', path, lines, line_no)
+            continue
+
+        if migration and postgres and not down:
+            m = CREATE_INDEX_RE.match(stmt)
+            if m and not m.group(1) and table_key(m.group(2)) not in created:
+                rule = 'sql.postgres.index-not-concurrent'
+                if table_key(m.group(2)) in LARGE_TABLES:
+                    rule = 'sql.postgres.index-not-concurrent-large'
+                emit(rule, path, lines, line_no)
+
+
+for file_path in iter_files(ROOT):
+    analyze(file_path)
+PY
+}
+
+# Report every rule id in "$@" from SQL_FINDINGS_FILE; print the good message
+# when none of them fired.
+report_sql_rules() {
+  local good_msg="$1"; shift
+  local rule found=0
+  for rule in "$@"; do
+    local count
+    count=$(awk -F'\t' -v r="$rule" '$1==r' "$SQL_FINDINGS_FILE" 2>/dev/null | awk 'END{print NR+0}')
+    [[ "$count" -gt 0 ]] || continue
+    found=1
+    print_finding "${SQL_SEVERITY[$rule]}" "$count" "${SQL_SUMMARY[$rule]}" "[$rule] ${SQL_REMEDIATION[$rule]}"
+    local printed=0
+    while IFS=$'\t' read -r _rule file line code; do
+      [[ "$printed" -ge "$DETAIL_LIMIT" || "$printed" -ge "$MAX_DETAILED" ]] && break
+      print_code_sample "$file" "$line" "$code"
+      printed=$((printed + 1))
+    done < <(awk -F'\t' -v r="$rule" '$1==r' "$SQL_FINDINGS_FILE" 2>/dev/null)
+  done
+  if [[ "$found" -eq 0 ]]; then
+    print_finding "good" "$good_msg"
+  fi
+}
+
+# ────────────────────────────────────────────────────────────────────────────
+# Init
+# ────────────────────────────────────────────────────────────────────────────
+maybe_clear
+
+if ! is_machine_format; then
+echo -e "${BOLD}${CYAN}"
+cat <<'BANNER'
+╔══════════════════════════════════════════════════════════════════╗
+║  ███████╗ ██████╗ ██╗         ██╗   ██╗██████╗ ███████╗          ║
+║  ██╔════╝██╔═══██╗██║         ██║   ██║██╔══██╗██╔════╝          ║
+║  ███████╗██║   ██║██║         ██║   ██║██████╔╝███████╗          ║
+║  ╚════██║██║▄▄ ██║██║         ██║   ██║██╔══██╗╚════██║          ║
+║  ███████║╚██████╔╝███████╗    ╚██████╔╝██████╔╝███████║          ║
+║  ╚══════╝ ╚══▀▀═╝ ╚══════╝     ╚═════╝ ╚═════╝ ╚══════╝          ║
+║                                                                  ║
+║  SQL module • migrations, unbounded DML, Postgres online DDL     ║
+║  Run standalone: modules/ubs-sql.sh --help                       ║
+╚══════════════════════════════════════════════════════════════════╝
+BANNER
+echo -e "${RESET}"
+fi
+
+PROJECT_DIR="$(abspath "$PROJECT_DIR")"
+build_find_cmd
+say "${WHITE}Project:${RESET}  ${CYAN}$PROJECT_DIR${RESET}"
+say "${WHITE}Started:${RESET}  ${GRAY}$(safe_date)${RESET}"
+
+# Count files with robust find
+TOTAL_FILES=$( ( set +o pipefail; "${FIND_CMD[@]}" 2>/dev/null || true ) | safe_count_files )
+TOTAL_FILES=$(( TOTAL_FILES + 0 ))
+say "${WHITE}Files:${RESET}    ${CYAN}$TOTAL_FILES source files (${INCLUDE_EXT})${RESET}"
+
+begin_scan_section
+
+HAS_ANALYZER=1
+run_sql_analyzer || HAS_ANALYZER=0
+trap '[[ -n "$SQL_FINDINGS_FILE" ]] && rm -f "$SQL_FINDINGS_FILE"' EXIT
+if [[ "$HAS_ANALYZER" -eq 0 ]]; then
+  say "${YELLOW}${WARN} python3 not found - SQL statement analysis disabled${RESET}"
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 1: DESTRUCTIVE MIGRATIONS
+# ═══════════════════════════════════════════════════════════════════════════
+if run_category 1; then
+print_header "1. DESTRUCTIVE MIGRATIONS"
+print_category "Detects: DROP TABLE/COLUMN/SCHEMA without IF EXISTS, TRUNCATE in forward migrations" \
+  "Irreversible DDL in an up migration deletes data the moment it deploys; down sections are exempt."
+
+print_subheader "Unguarded DROP / TRUNCATE in migrations"
+if [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  report_sql_rules "No unguarded destructive DDL in migrations" sql.migration.destructive-unguarded
+else
+  print_finding "info" 0 "python3 not available" "Install python3 to enable destructive migration checks"
+fi
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 2: UNBOUNDED DML
+# ═══════════════════════════════════════════════════════════════════════════
+if run_category 2; then
+print_header "2. UNBOUNDED DML"
+print_category "Detects: UPDATE and DELETE statements without a WHERE clause" \
+  "A missing predicate rewrites or wipes every row; tables created in the same file are exempt."
+
+print_subheader "UPDATE / DELETE without WHERE"
+if [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  report_sql_rules "Every UPDATE/DELETE is bounded by WHERE" sql.dml.missing-where
+else
+  print_finding "info" 0 "python3 not available" "Install python3 to enable unbounded DML checks"
+fi
+fi
+
+# ═══════════════════════════════════════════════════════════════════════════
+# CATEGORY 3: POSTGRES ONLINE INDEXING
+# ═══════════════════════════════════════════════════════════════════════════
+if run_category 3; then
+print_header "3. POSTGRES ONLINE INDEXING"
+print_category "Detects: CREATE INDEX without CONCURRENTLY on tables the migration did not create" \
+  "A plain index build blocks writes for its whole duration; list hot tables in UBS_SQL_LARGE_TABLES to escalate."
+
+print_subheader "Blocking index builds in migrations"
+if [[ "$HAS_ANALYZER" -eq 1 ]]; then
+  report_sql_rules "No blocking index builds on existing tables" sql.postgres.index-not-concurrent-large sql.postgres.index-not-concurrent
+else
+  print_finding "info" 0 "python3 not available" "Install python3 to enable Postgres indexing checks"
+fi
+fi
+
+end_scan_section
+
+# ═══════════════════════════════════════════════════════════════════════════
+# FINAL SUMMARY
+# ═══════════════════════════════════════════════════════════════════════════
+
+EXIT_CODE=0
+if [ "$CRITICAL_COUNT" -gt 0 ]; then EXIT_CODE=1; fi
+if [ "$FAIL_ON_WARNING" -eq 1 ] && [ $((CRITICAL_COUNT + WARNING_COUNT)) -gt 0 ]; then EXIT_CODE=1; fi
+
+if [[ "$FORMAT" == "json" ]]; then
+  emit_json_summary
+  exit "$EXIT_CODE"
+fi
+if [[ "$FORMAT" == "sarif" ]]; then
+  emit_sarif
+  exit "$EXIT_CODE"
+fi
+
+echo ""
+say "${BOLD}${WHITE}═══════════════════════════════════════════════════════════════════════════${RESET}"
+say "${BOLD}${CYAN}                    ${SHIELD} SCAN COMPLETE ${SHIELD}                                  ${RESET}"
+say "${BOLD}${WHITE}═══════════════════════════════════════════════════════════════════════════${RESET}"
+echo ""
+
+say "${WHITE}${BOLD}Summary Statistics:${RESET}"
+say "  ${WHITE}Files scanned:${RESET}    ${CYAN}$TOTAL_FILES${RESET}"
+say "  ${RED}${BOLD}Critical issues:${RESET}  ${RED}$CRITICAL_COUNT${RESET}"
+say "  ${YELLOW}Warning issues:${RESET}   ${YELLOW}$WARNING_COUNT${RESET}"
+say "  ${BLUE}Info items:${RESET}       ${BLUE}$INFO_COUNT${RESET}"
+echo ""
+
+if [ "$CRITICAL_COUNT" -eq 0 ] && [ "$WARNING_COUNT" -eq 0 ]; then
+  say "  ${GREEN}${BOLD}${SPARKLE} EXCELLENT! No critical or warning issues found ${SPARKLE}${RESET}"
+fi
+
+echo ""
+say "${DIM}Scan completed at: $(safe_date)${RESET}"
+
+if [[ -n "$OUTPUT_FILE" ]]; then
+  say "${GREEN}${CHECK} Full report saved to: ${CYAN}$OUTPUT_FILE${RESET}"
+fi
+if [[ -n "$SUMMARY_JSON" ]]; then
+  mkdir -p "$(dirname "$SUMMARY_JSON")" 2>/dev/null || true
+  printf '{"timestamp":"%s","files":%s,"critical":%s,"warning":%s,"info":%s}\n' \
+     "$(safe_date)" "$TOTAL_FILES" "$CRITICAL_COUNT" "$WARNING_COUNT" "$INFO_COUNT" >"$SUMMARY_JSON"
+fi
+
+echo ""
+say "${DIM}Add to pre-commit: ./ubs --ci --fail-on-warning --only=sql . > sql-bug-scan-report.txt${RESET}"
+echo ""
+
+exit "$EXIT_CODE"
@@ -0,0 +1,65 @@
+#!/usr/bin/env bash
+# Entry point for the ubs-tools OCI image.
+#
+#   docker run IMAGE scan [scan options]   hermetic scan of /src (read-only) → /out
+#   docker run IMAGE toolchain             print the pinned tool versions
+#   docker run IMAGE [ubs arguments]       run ubs directly (default: --help)
+#
+# Scan mode uses only what was pinned at image build time (modules, helpers,
+# ast-grep, Go, Node/TypeScript, and the Debian packages of the snapshot date
+# in the io.ubs.toolchain label; see /opt/ubs/TOOLCHAIN) and never
+# downloads modules or self-updates. `ubs docker-run` on the host wires up the
+# mounts; the variables below only need changing for custom layouts.
+set -Eeuo pipefail
+
+UBS_BIN="${UBS_BIN:-/app/ubs}"
+SRC_DIR="${UBS_SRC_DIR:-/src}"
+OUT_DIR="${UBS_OUT_DIR:-/out}"
+TOOLCHAIN_FILE="${UBS_TOOLCHAIN_FILE:-/opt/ubs/TOOLCHAIN}"
+
+# Accept `docker run IMAGE ubs --help` as well as `docker run IMAGE --help`.
+if [[ "${1:-}" == "ubs" ]]; then shift; fi
+if [[ "${1:-}" == "toolchain" ]]; then
+  cat "$TOOLCHAIN_FILE"
+  exit
+fi
+if [[ "${1:-}" != "scan" ]]; then
+  exec "$UBS_BIN" "$@"
+fi
+shift
+
+die(){ echo "ubs-docker: $*" >&2; exit 2; }
+
+[[ -d "$SRC_DIR" ]] || die "no project mounted at $SRC_DIR (use -v \"\$PWD:$SRC_DIR:ro\")"
+mkdir -p "$OUT_DIR" 2>/dev/null || true
+[[ -d "$OUT_DIR" && -w "$OUT_DIR" ]] || die "output directory $OUT_DIR is not writable (use -v \"\$PWD/ubs-reports:$OUT_DIR\")"
+
+# The mount is owned by the host user; let git read it for --staged/--diff,
+# permalinks and --owners without touching a (possibly unwritable) \$HOME.
+export GIT_CONFIG_COUNT=1 GIT_CONFIG_KEY_0=safe.directory GIT_CONFIG_VALUE_0='*'
+
+format="${UBS_OUTPUT_FORMAT:-text}"
+for arg in "$@"; do
+  case "$arg" in
+    --format=*) format="${arg#*=}";;
+  esac
+done
+case "$format" in
+  text) ext="txt";;
+  json|jsonl|sarif|toon) ext="$format";;
+  *) ext="out";;
+esac
+
+if [[ -f "$TOOLCHAIN_FILE" ]]; then
+  cp "$TOOLCHAIN_FILE" "$OUT_DIR/toolchain.txt" 2>/dev/null || true
+fi
+
+cd "$SRC_DIR"
+set +e
+"$UBS_BIN" --no-auto-update "$@" \
+  --report-json="$OUT_DIR/ubs-summary.json" \
+  --html-report="$OUT_DIR/ubs-report.html" \
+  "$SRC_DIR" | tee "$OUT_DIR/ubs.$ext"
+status="${PIPESTATUS[0]}"
+set -e
+exit "$status"
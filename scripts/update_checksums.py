@@ -50,7 +50,10 @@ def main():
         "java": "ubs-java.sh",
         "ruby": "ubs-ruby.sh",
         "swift": "ubs-swift.sh",
-        "elixir": "ubs-elixir.sh"
+        "elixir": "ubs-elixir.sh",
+        "sql": "ubs-sql.sh",
+        "proto": "ubs-proto.sh",
+        "ci": "ubs-ci.sh"
     }
 
     new_checksums = {}
@@ -0,0 +1,45 @@
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+#include <unistd.h>
+
+struct record {
+    char *name;
+    size_t len;
+};
+
+struct record *record_new(const char *name) {
+    struct record *r = malloc(sizeof(*r));
+    r->len = strlen(name);
+    r->name = malloc(r->len + 1);
+    memcpy(r->name, name, r->len + 1);
+    return r;
+}
+
+int grow(char **buf, size_t *cap) {
+    char *data = *buf;
+    data = realloc(data, *cap * 2);
+    *cap *= 2;
+    *buf = data;
+    return 0;
+}
+
+size_t load(const char *path, char *out, size_t n) {
+    FILE *fp = fopen(path, "rb");
+    size_t got = fread(out, 1, n, fp);
+    fclose(fp);
+    return got;
+}
+
+void drop_privileges(uid_t uid) {
+    setuid(uid);
+}
+
+void copy_header(int fd, char *dst, size_t n) {
+    read(fd, dst, n);
+}
+
+void record_free(struct record *r) {
+    free(r->name);
+    free(r);
+}
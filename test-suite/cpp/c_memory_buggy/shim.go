@@ -0,0 +1,27 @@
+package shim
+
+/*
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+static char *greet(const char *who) {
+    char *out = malloc(64);
+    sprintf(out, "hello %s", who);
+    return out;
+}
+
+static void label(char *dst, const char *src) {
+    strcpy(dst, src);
+}
+*/
+import "C"
+
+import "unsafe"
+
+func Greet(who string) string {
+	cwho := C.CString(who)
+	out := C.greet(cwho)
+	defer C.free(unsafe.Pointer(out))
+	return C.GoString(out)
+}
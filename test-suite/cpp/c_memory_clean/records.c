@@ -0,0 +1,68 @@
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+#include <unistd.h>
+
+struct record {
+    char *name;
+    size_t len;
+};
+
+struct record *record_new(const char *name) {
+    struct record *r = malloc(sizeof(*r));
+    if (r == NULL) {
+        return NULL;
+    }
+    r->len = strlen(name);
+    r->name = malloc(r->len + 1);
+    if (!r->name) {
+        free(r);
+        return NULL;
+    }
+    memcpy(r->name, name, r->len + 1);
+    return r;
+}
+
+int grow(char **buf, size_t *cap) {
+    char *tmp = realloc(*buf, *cap * 2);
+    if (!tmp) {
+        return -1;
+    }
+    *buf = tmp;
+    *cap *= 2;
+    return 0;
+}
+
+long load(const char *path, char *out, size_t n) {
+    FILE *fp = fopen(path, "rb");
+    if (!fp) {
+        return -1;
+    }
+    size_t got = fread(out, 1, n, fp);
+    if (got < n && ferror(fp)) {
+        fclose(fp);
+        return -1;
+    }
+    fclose(fp);
+    return (long)got;
+}
+
+int drop_privileges(uid_t uid) {
+    if (setuid(uid) != 0) {
+        return -1;
+    }
+    return 0;
+}
+
+ssize_t copy_header(int fd, char *dst, size_t n) {
+    ssize_t got = read(fd, dst, n);
+    return got;
+}
+
+void record_free(struct record *r) {
+    if (!r) {
+        return;
+    }
+    free(r->name);
+    free(r);
+}
@@ -0,0 +1,29 @@
+package shim
+
+/*
+#include <stdio.h>
+#include <stdlib.h>
+
+static char *greet(const char *who) {
+    char *out = malloc(64);
+    if (out == NULL) {
+        return NULL;
+    }
+    snprintf(out, 64, "hello %s", who);
+    return out;
+}
+*/
+import "C"
+
+import "unsafe"
+
+func Greet(who string) string {
+	cwho := C.CString(who)
+	defer C.free(unsafe.Pointer(cwho))
+	out := C.greet(cwho)
+	if out == nil {
+		return ""
+	}
+	defer C.free(unsafe.Pointer(out))
+	return C.GoString(out)
+}
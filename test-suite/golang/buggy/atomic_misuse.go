@@ -0,0 +1,89 @@
+package buggy
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type RequestStats struct {
+	hits    int64
+	errors  int64
+	started int32
+}
+
+func (s *RequestStats) Record(failed bool) {
+	atomic.AddInt64(&s.hits, 1)
+	if failed {
+		atomic.AddInt64(&s.errors, 1)
+	}
+}
+
+// BUG: plain reads race with the atomic adds above
+func (s *RequestStats) ErrorRate() float64 {
+	if s.hits == 0 {
+		return 0
+	}
+	return float64(s.errors) / float64(s.hits)
+}
+
+// BUG: plain write to a field every other path updates atomically
+func (s *RequestStats) Reset() {
+	s.hits = 0
+	atomic.StoreInt64(&s.errors, 0)
+}
+
+// BUG: two goroutines can both see 0 and both start the worker
+func (s *RequestStats) StartOnce(run func()) {
+	if atomic.LoadInt32(&s.started) == 0 {
+		atomic.StoreInt32(&s.started, 1)
+		go run()
+	}
+}
+
+type Limiter struct {
+	inFlight atomic.Int64
+	max      int64
+}
+
+// BUG: increments lost when callers race between Load and Store
+func (l *Limiter) Acquire() bool {
+	n := l.inFlight.Load()
+	if n >= l.max {
+		return false
+	}
+	l.inFlight.Store(n + 1)
+	return true
+}
+
+type Registry struct {
+	mu    sync.Mutex
+	names map[string]int
+}
+
+// BUG: value receiver locks a copy of the mutex
+func (r Registry) Lookup(name string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.names[name]
+}
+
+type Shard struct {
+	Registry
+	id int
+}
+
+// BUG: Shard embeds Registry, so the parameter copies its mutex
+func describe(s Shard) string {
+	return string(rune('A' + s.id))
+}
+
+var shards []Shard
+
+// BUG: range value copies each shard (and its mutex) per iteration
+func totalNames() int {
+	total := 0
+	for _, s := range shards {
+		total += len(s.names)
+	}
+	return total
+}
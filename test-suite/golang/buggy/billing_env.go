@@ -0,0 +1,45 @@
+package buggy
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+type billingService struct {
+	db *sql.DB
+}
+
+// chargeHandler reads configuration on every request instead of at startup.
+func (s *billingService) chargeHandler(w http.ResponseWriter, r *http.Request) {
+	currency := os.Getenv("BILLING_CURRENCY")
+	maxRetries, _ := strconv.Atoi(os.Getenv("BILLING_MAX_RETRIES"))
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if _, err = s.db.ExecContext(r.Context(), "SELECT charge($1)", currency); err == nil {
+			break
+		}
+	}
+	w.Header().Set("X-Currency", currency)
+	fmt.Fprintln(w, err)
+}
+
+func openDatabase() (*sql.DB, error) {
+	// An unset DATABASE_URL reaches sql.Open as "".
+	dsn := os.Getenv("DATABASE_URL")
+	return sql.Open("postgres", dsn)
+}
+
+func startServer(h http.Handler) error {
+	timeoutRaw := os.Getenv("HTTP_TIMEOUT_SECONDS")
+	secs, _ := strconv.Atoi(timeoutRaw)
+	srv := &http.Server{
+		Addr:        ":" + os.Getenv("PORT"),
+		Handler:     h,
+		ReadTimeout: time.Duration(secs) * time.Second,
+	}
+	return srv.ListenAndServe()
+}
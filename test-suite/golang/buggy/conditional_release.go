@@ -0,0 +1,66 @@
+package buggy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// BUG: the short-read return skips f.Close().
+func readMagic(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return nil, err
+	}
+	f.Close()
+	return magic, nil
+}
+
+type quotaTable struct {
+	mu     sync.Mutex
+	quotas map[string]int
+}
+
+// BUG: the unknown-tenant return keeps the mutex locked forever.
+func (q *quotaTable) Take(tenant string) error {
+	q.mu.Lock()
+	left, ok := q.quotas[tenant]
+	if !ok {
+		return errors.New("unknown tenant")
+	}
+	q.quotas[tenant] = left - 1
+	q.mu.Unlock()
+	return nil
+}
+
+// BUG: cancel only runs when the probe succeeds.
+func probe(ctx context.Context, ping func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	if err := ping(ctx); err != nil {
+		return err
+	}
+	cancel()
+	return nil
+}
+
+// BUG: a mode outside 0 and 1 skips both cases and leaves f open.
+func rotate(path string, mode int) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	switch mode {
+	case 0:
+		f.Close()
+	case 1:
+		f.Close()
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package buggy
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+const requestIDKey = "request_id"
+
+type loggerKey struct{}
+type settingsKey struct{}
+
+type Settings struct {
+	Region, Tier, Locale, Currency, Timezone string
+	RateLimit, Burst                         int
+	FeatureFlags                             map[string]bool
+}
+
+// withRequest keys values by plain strings/ints and stashes heavy objects.
+func withRequest(ctx context.Context, r *http.Request, logger *slog.Logger, settings Settings) context.Context {
+	ctx = context.WithValue(ctx, "user_id", r.Header.Get("X-User"))
+	ctx = context.WithValue(ctx, requestIDKey, r.Header.Get("X-Request-ID"))
+	ctx = context.WithValue(ctx, 42, true)
+	ctx = context.WithValue(ctx, loggerKey{}, logger)
+	ctx = context.WithValue(ctx, settingsKey{}, settings)
+	return ctx
+}
+
+func handleCheckout(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+	settings := r.Context().Value(settingsKey{}).(Settings)
+	if settings.Tier == "free" {
+		http.Error(w, "upgrade required", http.StatusPaymentRequired)
+		return
+	}
+	w.Write([]byte(userID))
+}
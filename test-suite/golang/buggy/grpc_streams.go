@@ -0,0 +1,60 @@
+package buggy
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"google.golang.org/grpc"
+
+	pb "example.com/shop/gen/shop/v1"
+)
+
+type orderServer struct {
+	pb.UnimplementedOrderServiceServer
+	updates chan *pb.Order
+}
+
+// Server-streaming handler: the client may disconnect at any time, but the
+// Send error is dropped so the loop keeps producing into a dead stream.
+func (s *orderServer) WatchOrders(req *pb.WatchOrdersRequest, stream pb.OrderService_WatchOrdersServer) error {
+	for order := range s.updates {
+		stream.Send(order)
+	}
+	return nil
+}
+
+// Client-streaming handler that discards the Recv error entirely.
+func (s *orderServer) UploadOrders(stream pb.OrderService_UploadOrdersServer) error {
+	count := 0
+	for {
+		order, _ := stream.Recv()
+		if order == nil {
+			break
+		}
+		count++
+	}
+	_ = stream.SendAndClose(&pb.UploadSummary{Count: int32(count)})
+	return nil
+}
+
+// Only io.EOF is checked; any other error yields a nil order and a panic.
+func tailOrders(ctx context.Context, client pb.OrderServiceClient) {
+	watch, err := client.WatchOrders(ctx, &pb.WatchOrdersRequest{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for {
+		order, err := watch.Recv()
+		if err == io.EOF {
+			return
+		}
+		log.Println(order.Id)
+	}
+}
+
+func relay(stream grpc.ServerStream, msgs []*pb.Order) {
+	for _, m := range msgs {
+		stream.SendMsg(m)
+	}
+}
@@ -0,0 +1,48 @@
+package buggy
+
+import "fmt"
+
+type LineItem struct {
+	SKU      string
+	Price    float64
+	Quantity int
+}
+
+type Account struct {
+	ID      string
+	Balance float64
+}
+
+func cartTotal(items []LineItem) float64 {
+	var total float64
+	for _, item := range items {
+		total += item.Price * float64(item.Quantity)
+	}
+	return total
+}
+
+func applyRefunds(acct *Account, refunds []float64) {
+	for _, r := range refunds {
+		acct.Balance += r
+	}
+}
+
+func toCents(amount float64) int64 {
+	return int64(amount * 100)
+}
+
+func chargeCents(item LineItem) int {
+	return int(item.Price * 100)
+}
+
+func isPaidInFull(paid, due float64) bool {
+	return paid == due
+}
+
+func hasDiscount(rate float64) bool {
+	return rate != 0.15
+}
+
+func describe(acct Account) string {
+	return fmt.Sprintf("%s: %.2f", acct.ID, acct.Balance)
+}
@@ -0,0 +1,51 @@
+package buggy
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+)
+
+var verbosePricing = flag.Bool("verbose-pricing", false, "log every price lookup")
+
+var (
+	sessionOwners = map[string]string{}
+	loginAudit    []string
+	priceBook     map[string]float64
+)
+
+func init() {
+	// BUG: parses os.Args before main (or go test) registers its flags
+	flag.Parse()
+
+	// BUG: every importer (and every test binary) now needs network access
+	resp, err := http.Get("https://config.internal/pricing.json")
+	if err == nil {
+		defer resp.Body.Close()
+		_ = json.NewDecoder(resp.Body).Decode(&priceBook)
+	}
+
+	// BUG: file read at import time; failure is silently ignored
+	if raw, err := os.ReadFile("/etc/shop/sessions.json"); err == nil {
+		_ = json.Unmarshal(raw, &sessionOwners)
+	}
+}
+
+// RememberSession is called from concurrent HTTP handlers.
+func RememberSession(id, user string) {
+	// BUG: concurrent map writes and a racy append on package globals
+	sessionOwners[id] = user
+	loginAudit = append(loginAudit, "login:"+user)
+}
+
+func ForgetSession(id string) {
+	delete(sessionOwners, id) // BUG: unsynchronized delete
+}
+
+func LookupPrice(sku string) float64 {
+	if *verbosePricing {
+		println("lookup", sku)
+	}
+	return priceBook[sku]
+}
@@ -0,0 +1,54 @@
+package buggy
+
+import "fmt"
+
+type ValidationError struct {
+	Field string
+}
+
+func (e *ValidationError) Error() string { return "invalid " + e.Field }
+
+// validateOrder wraps a nil *ValidationError in a non-nil error on the happy path.
+func validateOrder(qty int) error {
+	var verr *ValidationError
+	if qty <= 0 {
+		verr = &ValidationError{Field: "qty"}
+	}
+	return verr
+}
+
+func lookupSKU(sku string) *ValidationError {
+	if sku == "" {
+		return &ValidationError{Field: "sku"}
+	}
+	return nil
+}
+
+func checkSKU(sku string) error {
+	return lookupSKU(sku)
+}
+
+func placeOrder(sku string, qty int) {
+	var err error
+	err = lookupSKU(sku)
+	if err != nil {
+		fmt.Println("rejected:", err) // always taken, even for valid SKUs
+		return
+	}
+	fmt.Println("placed", sku, qty)
+}
+
+// Store persists order ids.
+type Store interface {
+	Save(id string) error
+}
+
+// memoryStore implements Store.
+type memoryStore struct {
+	ids []string
+}
+
+func (m *memoryStore) Save(id string) error {
+	m.ids = append(m.ids, id)
+	return nil
+}
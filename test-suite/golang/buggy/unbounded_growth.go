@@ -0,0 +1,46 @@
+package buggy
+
+import (
+	"net/http"
+	"sync"
+)
+
+type session struct {
+	User  string
+	Token string
+}
+
+var (
+	sessionCache = map[string]*session{}
+	cacheMu      sync.Mutex
+	auditTrail   []string
+)
+
+var seenClients sync.Map
+
+func loginHandler(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Token")
+	cacheMu.Lock()
+	sessionCache[token] = &session{User: r.FormValue("user"), Token: token}
+	auditTrail = append(auditTrail, r.RemoteAddr)
+	cacheMu.Unlock()
+	seenClients.Store(r.RemoteAddr, true)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sendReceipt(addr string) {}
+
+func checkoutHandler(w http.ResponseWriter, r *http.Request) {
+	email := r.FormValue("email")
+	go sendReceipt(email)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func registerRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/export", func(w http.ResponseWriter, r *http.Request) {
+		go func() {
+			sendReceipt(r.URL.Query().Get("to"))
+		}()
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
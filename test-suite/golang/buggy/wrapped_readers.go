@@ -0,0 +1,49 @@
+package buggy
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+)
+
+// BUG: gz.Close() releases the decompressor but never closes f.
+func countGzipLines(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return 0, err
+	}
+	defer gz.Close()
+	lines := 0
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines, scanner.Err()
+}
+
+// BUG: the gzip writer is never closed, so the footer is never flushed.
+func writeGzip(path string, payload []byte) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	zw := gzip.NewWriter(out)
+	_, err = zw.Write(payload)
+	return err
+}
+
+// BUG: io.NopCloser hides the file; the caller's Close is a no-op.
+func openReport(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	rc := io.NopCloser(f)
+	return rc, nil
+}
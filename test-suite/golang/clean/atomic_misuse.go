@@ -0,0 +1,92 @@
+package clean
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type RequestStats struct {
+	hits    int64
+	errors  int64
+	started int32
+}
+
+func (s *RequestStats) Record(failed bool) {
+	atomic.AddInt64(&s.hits, 1)
+	if failed {
+		atomic.AddInt64(&s.errors, 1)
+	}
+}
+
+func (s *RequestStats) ErrorRate() float64 {
+	hits := atomic.LoadInt64(&s.hits)
+	if hits == 0 {
+		return 0
+	}
+	return float64(atomic.LoadInt64(&s.errors)) / float64(hits)
+}
+
+func (s *RequestStats) Reset() {
+	atomic.StoreInt64(&s.hits, 0)
+	atomic.StoreInt64(&s.errors, 0)
+}
+
+// CompareAndSwap makes the check and the update one step.
+func (s *RequestStats) StartOnce(run func()) {
+	if atomic.CompareAndSwapInt32(&s.started, 0, 1) {
+		go run()
+	}
+}
+
+type Limiter struct {
+	inFlight atomic.Int64
+	max      int64
+}
+
+func (l *Limiter) Acquire() bool {
+	for {
+		n := l.inFlight.Load()
+		if n >= l.max {
+			return false
+		}
+		if l.inFlight.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
+
+type Registry struct {
+	mu    sync.Mutex
+	names map[string]int
+}
+
+func (r *Registry) Lookup(name string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.names[name]
+}
+
+type Shard struct {
+	Registry
+	id int
+}
+
+func describe(s *Shard) string {
+	return string(rune('A' + s.id))
+}
+
+var shards []*Shard
+
+func totalNames() int {
+	total := 0
+	for _, s := range shards {
+		total += s.lookupCount()
+	}
+	return total
+}
+
+func (s *Shard) lookupCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.names)
+}
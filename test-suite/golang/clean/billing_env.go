@@ -0,0 +1,68 @@
+package clean
+
+import (
+	"cmp"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config is loaded once at startup; handlers never touch the environment.
+type Config struct {
+	DatabaseURL string
+	Addr        string
+	Currency    string
+	MaxRetries  int
+	ReadTimeout time.Duration
+}
+
+func LoadConfig() (Config, error) {
+	cfg := Config{Addr: ":8080", MaxRetries: 3, ReadTimeout: 10 * time.Second}
+
+	cfg.DatabaseURL = os.Getenv("DATABASE_URL")
+	if cfg.DatabaseURL == "" {
+		return Config{}, errors.New("DATABASE_URL is required")
+	}
+	if port, ok := os.LookupEnv("PORT"); ok && port != "" {
+		cfg.Addr = ":" + port
+	}
+	cfg.Currency = cmp.Or(os.Getenv("BILLING_CURRENCY"), "USD")
+	if raw := os.Getenv("BILLING_MAX_RETRIES"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("BILLING_MAX_RETRIES: %w", err)
+		}
+		cfg.MaxRetries = n
+	}
+	secs, err := strconv.Atoi(cmp.Or(os.Getenv("HTTP_TIMEOUT_SECONDS"), "10"))
+	if err != nil {
+		return Config{}, fmt.Errorf("HTTP_TIMEOUT_SECONDS: %w", err)
+	}
+	cfg.ReadTimeout = time.Duration(secs) * time.Second
+	return cfg, nil
+}
+
+type billingService struct {
+	db  *sql.DB
+	cfg Config
+}
+
+func (s *billingService) chargeHandler(w http.ResponseWriter, r *http.Request) {
+	var err error
+	for attempt := 0; attempt < s.cfg.MaxRetries; attempt++ {
+		if _, err = s.db.ExecContext(r.Context(), "SELECT charge($1)", s.cfg.Currency); err == nil {
+			break
+		}
+	}
+	w.Header().Set("X-Currency", s.cfg.Currency)
+	fmt.Fprintln(w, err)
+}
+
+func startServer(cfg Config, h http.Handler) error {
+	srv := &http.Server{Addr: cfg.Addr, Handler: h, ReadTimeout: cfg.ReadTimeout}
+	return srv.ListenAndServe()
+}
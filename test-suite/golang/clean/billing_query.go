@@ -0,0 +1,17 @@
+package clean
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"os"
+)
+
+// cmp.Or picks the first non-empty value; it is not a query builder's Or, so
+// an env default next to a parameterized query is not SQL concatenation.
+func ListInvoices(ctx context.Context, db *sql.DB, customerID string) (*sql.Rows, error) {
+	region := cmp.Or(os.Getenv("BILLING_REGION"), "us-east-1")
+	return db.QueryContext(ctx,
+		"SELECT id, total FROM invoices WHERE customer_id = $1 AND region = $2",
+		customerID, region)
+}
@@ -0,0 +1,63 @@
+package clean
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+type ctxKey int
+
+const (
+	userIDKey ctxKey = iota
+	requestIDKey
+)
+
+type settingsKey struct{}
+
+type Settings struct {
+	Region, Tier, Locale, Currency, Timezone string
+	RateLimit, Burst                         int
+	FeatureFlags                             map[string]bool
+}
+
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey, id)
+}
+
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey).(string)
+	return id, ok
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// WithSettings stores a pointer; the settings themselves stay shared.
+func WithSettings(ctx context.Context, s *Settings) context.Context {
+	return context.WithValue(ctx, settingsKey{}, s)
+}
+
+func SettingsFromContext(ctx context.Context) (*Settings, bool) {
+	s, ok := ctx.Value(settingsKey{}).(*Settings)
+	return s, ok
+}
+
+type checkoutHandler struct {
+	logger *slog.Logger
+}
+
+func (h *checkoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+	if s, ok := SettingsFromContext(r.Context()); ok && s.Tier == "free" {
+		http.Error(w, "upgrade required", http.StatusPaymentRequired)
+		return
+	}
+	h.logger.Info("checkout", "user", userID)
+	w.Write([]byte(userID))
+}
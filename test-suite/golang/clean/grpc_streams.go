@@ -0,0 +1,67 @@
+package clean
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+
+	"google.golang.org/grpc"
+
+	pb "example.com/shop/gen/shop/v1"
+)
+
+type orderServer struct {
+	pb.UnimplementedOrderServiceServer
+	updates chan *pb.Order
+}
+
+func (s *orderServer) WatchOrders(req *pb.WatchOrdersRequest, stream pb.OrderService_WatchOrdersServer) error {
+	for order := range s.updates {
+		if err := stream.Send(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *orderServer) UploadOrders(stream pb.OrderService_UploadOrdersServer) error {
+	count := 0
+	for {
+		_, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		count++
+	}
+	return stream.SendAndClose(&pb.UploadSummary{Count: int32(count)})
+}
+
+func tailOrders(ctx context.Context, client pb.OrderServiceClient) error {
+	watch, err := client.WatchOrders(ctx, &pb.WatchOrdersRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		order, err := watch.Recv()
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+		log.Println(order.Id)
+	}
+}
+
+func relay(stream grpc.ServerStream, msgs []*pb.Order) error {
+	for _, m := range msgs {
+		if err := stream.SendMsg(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package clean
+
+import (
+	"fmt"
+	"math"
+)
+
+type LineItem struct {
+	SKU        string
+	PriceCents int64
+	Quantity   int64
+}
+
+type Account struct {
+	ID           string
+	BalanceCents int64
+}
+
+const epsilon = 1e-9
+
+func cartTotal(items []LineItem) int64 {
+	var total int64
+	for _, item := range items {
+		total += item.PriceCents * item.Quantity
+	}
+	return total
+}
+
+func applyRefunds(acct *Account, refunds []int64) {
+	for _, r := range refunds {
+		acct.BalanceCents += r
+	}
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < epsilon
+}
+
+func isUnset(rate float64) bool {
+	return rate == 0
+}
+
+func average(samples []float64) float64 {
+	var sum float64
+	for _, s := range samples {
+		sum += s
+	}
+	return sum / float64(len(samples))
+}
+
+func describe(acct Account) string {
+	return fmt.Sprintf("%s: %d.%02d", acct.ID, acct.BalanceCents/100, acct.BalanceCents%100)
+}
@@ -0,0 +1,75 @@
+package clean
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// SessionStore owns its state; callers share a *SessionStore, not globals.
+type SessionStore struct {
+	mu     sync.Mutex
+	owners map[string]string
+	audit  []string
+}
+
+func NewSessionStore() *SessionStore {
+	return &SessionStore{owners: make(map[string]string)}
+}
+
+func (s *SessionStore) Remember(id, user string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.owners[id] = user
+	s.audit = append(s.audit, "login:"+user)
+}
+
+var (
+	priceMu   sync.RWMutex
+	priceBook = map[string]float64{}
+)
+
+func SetPrice(sku string, price float64) {
+	priceMu.Lock()
+	defer priceMu.Unlock()
+	priceBook[sku] = price
+}
+
+func LookupPrice(sku string) (float64, bool) {
+	priceMu.RLock()
+	defer priceMu.RUnlock()
+	price, ok := priceBook[sku]
+	return price, ok
+}
+
+// defaultTimeouts is filled once during package initialization and read-only afterwards.
+var defaultTimeouts = map[string]int{}
+
+func init() {
+	defaultTimeouts["read"] = 5
+	defaultTimeouts["write"] = 10
+}
+
+// LoadPricing is called explicitly from main so failures can be reported.
+func LoadPricing(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch pricing: %w", err)
+	}
+	defer resp.Body.Close()
+	prices := map[string]float64{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&prices); err != nil {
+		return fmt.Errorf("decode pricing: %w", err)
+	}
+	for sku, price := range prices {
+		SetPrice(sku, price)
+	}
+	return nil
+}
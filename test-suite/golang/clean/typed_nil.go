@@ -0,0 +1,55 @@
+package clean
+
+import "fmt"
+
+type ValidationError struct {
+	Field string
+}
+
+func (e *ValidationError) Error() string { return "invalid " + e.Field }
+
+func validateOrder(qty int) error {
+	if qty <= 0 {
+		return &ValidationError{Field: "qty"}
+	}
+	return nil
+}
+
+func lookupSKU(sku string) *ValidationError {
+	if sku == "" {
+		return &ValidationError{Field: "sku"}
+	}
+	return nil
+}
+
+func checkSKU(sku string) error {
+	if verr := lookupSKU(sku); verr != nil {
+		return verr
+	}
+	return nil
+}
+
+func placeOrder(sku string, qty int) {
+	if verr := lookupSKU(sku); verr != nil {
+		fmt.Println("rejected:", verr)
+		return
+	}
+	fmt.Println("placed", sku, qty)
+}
+
+// Store persists order ids.
+type Store interface {
+	Save(id string) error
+}
+
+// memoryStore implements Store.
+type memoryStore struct {
+	ids []string
+}
+
+var _ Store = (*memoryStore)(nil)
+
+func (m *memoryStore) Save(id string) error {
+	m.ids = append(m.ids, id)
+	return nil
+}
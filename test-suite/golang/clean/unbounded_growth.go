@@ -0,0 +1,81 @@
+package clean
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+type session struct {
+	User    string
+	Expires time.Time
+}
+
+var (
+	sessionCache = map[string]*session{}
+	cacheMu      sync.Mutex
+	recentErrors []string
+)
+
+var receiptJobs = make(chan string, 128)
+
+var exportSlots = semaphore.NewWeighted(8)
+
+func loginHandler(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Token")
+	cacheMu.Lock()
+	sessionCache[token] = &session{User: r.FormValue("user"), Expires: time.Now().Add(time.Hour)}
+	if len(recentErrors) > 100 {
+		recentErrors = recentErrors[len(recentErrors)-100:]
+	}
+	recentErrors = append(recentErrors, r.RemoteAddr)
+	cacheMu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func evictExpired(now time.Time) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	for token, s := range sessionCache {
+		if now.After(s.Expires) {
+			delete(sessionCache, token)
+		}
+	}
+}
+
+func sendReceipt(addr string) {}
+
+func startReceiptWorkers(ctx context.Context, n int) {
+	for i := 0; i < n; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case addr := <-receiptJobs:
+					sendReceipt(addr)
+				}
+			}
+		}()
+	}
+}
+
+func checkoutHandler(w http.ResponseWriter, r *http.Request) {
+	receiptJobs <- r.FormValue("email")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func exportHandler(w http.ResponseWriter, r *http.Request) {
+	if err := exportSlots.Acquire(r.Context(), 1); err != nil {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+		return
+	}
+	go func() {
+		defer exportSlots.Release(1)
+		sendReceipt(r.URL.Query().Get("to"))
+	}()
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,61 @@
+package clean
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/tls"
+	"net"
+	"os"
+)
+
+// Both layers are closed: gz first (checksum/decompressor), then f.
+func countGzipLines(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return 0, err
+	}
+	defer gz.Close()
+	lines := 0
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines, scanner.Err()
+}
+
+// The writer is closed explicitly so its error (and footer) are not lost.
+func writeGzip(path string, payload []byte) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	zw := gzip.NewWriter(out)
+	if _, err := zw.Write(payload); err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// Closing the TLS listener closes the TCP listener it wraps.
+func serveTLS(addr string, cfg *tls.Config, handle func(net.Conn)) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	tln := tls.NewListener(ln, cfg)
+	defer tln.Close()
+	for {
+		conn, err := tln.Accept()
+		if err != nil {
+			return err
+		}
+		go handle(conn)
+	}
+}
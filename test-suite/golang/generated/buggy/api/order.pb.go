@@ -0,0 +1,15 @@
+package api
+
+// BUG: the "Code generated ... DO NOT EDIT." header was deleted while patching this file
+
+type Order struct {
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
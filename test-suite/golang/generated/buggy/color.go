@@ -0,0 +1,12 @@
+package shop
+
+//go:generate stringer -type=Color
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	// BUG: added without rerunning go generate; Blue.String() prints "Color(2)"
+	Blue
+)
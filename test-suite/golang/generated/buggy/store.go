@@ -0,0 +1,12 @@
+package shop
+
+import "context"
+
+//go:generate mockgen -source=store.go -destination=mocks/mock_store.go -package=mocks
+
+type Store interface {
+	Get(ctx context.Context, id string) (string, error)
+	Put(ctx context.Context, id, value string) error
+	// Added after the mock was generated.
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,21 @@
+package shop
+
+//go:generate stringer -type=Color
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+// ParseColor is hand-written, so it stays out of color_string.go.
+func ParseColor(s string) (Color, bool) {
+	for i := Red; i <= Blue; i++ {
+		if i.String() == s {
+			return i, true
+		}
+	}
+	return 0, false
+}
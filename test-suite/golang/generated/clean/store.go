@@ -0,0 +1,11 @@
+package shop
+
+import "context"
+
+//go:generate mockgen -source=store.go -destination=mocks/mock_store.go -package=mocks
+
+type Store interface {
+	Get(ctx context.Context, id string) (string, error)
+	Put(ctx context.Context, id, value string) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,20 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Runs modules/helpers/resource_lifecycle_go.go directly so manifest cases can
+# assert on its own flags, output, and exit codes (0/1/2/3). `go run` folds
+# every non-zero status into 1, so the helper is built and executed instead.
+# --ci (added by the manifest runner to every command) is dropped.
+
+ROOT_DIR="$(cd -- "$(dirname "${BASH_SOURCE[0]}")/../.." && pwd)"
+work="$(mktemp -d)"
+trap 'rm -rf "$work"' EXIT
+go build -o "$work/resource_lifecycle_go" "$ROOT_DIR/modules/helpers/resource_lifecycle_go.go"
+
+args=()
+for arg in "$@"; do
+  [[ "$arg" == "--ci" ]] || args+=("$arg")
+done
+status=0
+"$work/resource_lifecycle_go" "${args[@]}" || status=$?
+exit "$status"
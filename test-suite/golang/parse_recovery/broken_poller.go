@@ -0,0 +1,28 @@
+package parserecovery
+
+import (
+	"context"
+	"time"
+)
+
+// pollOnce leaks its cancel func; the leak must still be reported even though
+// a later function in this file does not compile.
+func pollOnce(ctx context.Context, fetch func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second) // want "context\.With\* without deferred cancel"
+	_ = cancel
+	return fetch(ctx)
+}
+
+// summarize has a missing closing parenthesis (intentional syntax error).
+func summarize(values []int) int {
+	total := sum(values...,
+	return total // want "Go files with syntax errors"
+}
+
+func sum(values ...int) int {
+	n := 0
+	for _, v := range values {
+		n += v
+	}
+	return n
+}
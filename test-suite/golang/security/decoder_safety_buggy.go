@@ -0,0 +1,68 @@
+package security
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+
+	"gopkg.in/yaml.v2"
+)
+
+type treeNode struct {
+	Name     string     `yaml:"name"`
+	Children []treeNode `yaml:"children"`
+}
+
+func decodeAnything(w http.ResponseWriter, r *http.Request) {
+	var payload interface{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+func decodeAnyMap(r *http.Request) (map[string]any, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	err = json.Unmarshal(body, &fields)
+	return fields, err
+}
+
+func serveGob(ln net.Listener) error {
+	conn, err := ln.Accept()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var msg interface{}
+	dec := gob.NewDecoder(conn)
+	return dec.Decode(&msg)
+}
+
+type feed struct {
+	Title string `xml:"title"`
+}
+
+func parseFeed(r *http.Request) (*feed, error) {
+	var f feed
+	dec := xml.NewDecoder(r.Body)
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	err := dec.Decode(&f)
+	return &f, err
+}
+
+func parseTree(r *http.Request) (*treeNode, error) {
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	var root treeNode
+	err = yaml.Unmarshal(raw, &root)
+	return &root, err
+}
@@ -0,0 +1,86 @@
+package security
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+const maxDecodeBytes int64 = 1 << 20
+
+type createOrder struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type envelope struct {
+	Kind string `gob:"kind"`
+}
+
+type feed struct {
+	Title string `xml:"title"`
+}
+
+type serviceConfig struct {
+	Name    string   `yaml:"name"`
+	Regions []string `yaml:"regions"`
+}
+
+func decodeOrder(w http.ResponseWriter, r *http.Request) (*createOrder, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxDecodeBytes)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	var order createOrder
+	err := dec.Decode(&order)
+	return &order, err
+}
+
+func decodeLimitedAny(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxDecodeBytes)
+	var payload interface{}
+	err := json.NewDecoder(r.Body).Decode(&payload)
+	return payload, err
+}
+
+func serveGob(ln net.Listener) error {
+	conn, err := ln.Accept()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var msg envelope
+	return gob.NewDecoder(io.LimitReader(conn, maxDecodeBytes)).Decode(&msg)
+}
+
+func parseFeed(w http.ResponseWriter, r *http.Request) (*feed, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxDecodeBytes)
+	var f feed
+	err := xml.NewDecoder(r.Body).Decode(&f)
+	return &f, err
+}
+
+func parseConfig(w http.ResponseWriter, r *http.Request) (*serviceConfig, error) {
+	raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDecodeBytes))
+	if err != nil {
+		return nil, err
+	}
+	var cfg serviceConfig
+	err = yaml.Unmarshal(raw, &cfg)
+	return &cfg, err
+}
+
+func loadLocalConfig(path string) (map[string]any, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	err = yaml.Unmarshal(raw, &out)
+	return out, err
+}
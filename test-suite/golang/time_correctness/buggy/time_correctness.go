@@ -0,0 +1,57 @@
+package timecorrectness
+
+import (
+	"fmt"
+	"time"
+)
+
+const billingLayout = "2006-01-02 15:04"
+
+type Invoice struct {
+	ID       string
+	IssuedAt time.Time
+	DueAt    time.Time
+}
+
+func measure(work func()) int64 {
+	start := time.Now().UnixNano()
+	work()
+	return time.Now().UnixNano() - start
+}
+
+func elapsedSeconds(begin, end time.Time) int64 {
+	return end.Unix() - begin.Unix()
+}
+
+func parseCutoff(raw string) (time.Time, error) {
+	// Interpreted as UTC even though callers treat it as local business hours.
+	return time.Parse("2006-01-02 15:04:05", raw)
+}
+
+func parseBilling(raw string) (time.Time, error) {
+	return time.Parse(billingLayout, raw)
+}
+
+func parseDay(raw string) (time.Time, error) {
+	return time.Parse(time.DateOnly, raw)
+}
+
+func dueToday(inv Invoice, today time.Time) bool {
+	return inv.DueAt == today
+}
+
+func sameIssue(a, b Invoice) bool {
+	if a.IssuedAt != b.IssuedAt {
+		return false
+	}
+	return a.ID == b.ID
+}
+
+func isMidnight(t time.Time) bool {
+	midnight := t.Truncate(24 * time.Hour)
+	return t == midnight
+}
+
+func report(inv Invoice) string {
+	return fmt.Sprintf("%s due %s", inv.ID, inv.DueAt.Format(time.RFC3339))
+}
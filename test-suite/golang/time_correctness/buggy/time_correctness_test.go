@@ -0,0 +1,15 @@
+package timecorrectness
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMeasureRunsWork(t *testing.T) {
+	done := false
+	go measure(func() { done = true })
+	time.Sleep(50 * time.Millisecond)
+	if !done {
+		t.Fatal("work did not run")
+	}
+}
@@ -0,0 +1,60 @@
+package timecorrectness
+
+import (
+	"fmt"
+	"time"
+)
+
+const billingLayout = "2006-01-02 15:04 MST"
+
+type Invoice struct {
+	ID       string
+	IssuedAt time.Time
+	DueAt    time.Time
+}
+
+func measure(work func()) time.Duration {
+	start := time.Now()
+	work()
+	return time.Since(start)
+}
+
+func elapsed(begin, end time.Time) time.Duration {
+	return end.Sub(begin)
+}
+
+func expiresAt(ttl time.Duration) int64 {
+	// Absolute timestamps sent to other systems are wall-clock by design.
+	return time.Now().Add(ttl).Unix()
+}
+
+func parseCutoff(raw string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04:05", raw, loc)
+}
+
+func parseBilling(raw string) (time.Time, error) {
+	return time.Parse(billingLayout, raw)
+}
+
+func parseStamp(raw string) (time.Time, error) {
+	return time.Parse(time.RFC3339, raw)
+}
+
+func dueToday(inv Invoice, today time.Time) bool {
+	return inv.DueAt.Equal(today)
+}
+
+func sameIssue(a, b Invoice) bool {
+	if !a.IssuedAt.Equal(b.IssuedAt) {
+		return false
+	}
+	return a.ID == b.ID
+}
+
+func isUnset(inv Invoice) bool {
+	return inv.DueAt.IsZero()
+}
+
+func report(inv Invoice) string {
+	return fmt.Sprintf("%s due %s", inv.ID, inv.DueAt.Format(time.RFC3339))
+}
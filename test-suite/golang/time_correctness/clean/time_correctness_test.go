@@ -0,0 +1,16 @@
+package timecorrectness
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMeasureRunsWork(t *testing.T) {
+	done := make(chan struct{})
+	go measure(func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("work did not run")
+	}
+}
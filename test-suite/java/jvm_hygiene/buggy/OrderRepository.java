@@ -0,0 +1,55 @@
+import java.io.BufferedReader;
+import java.io.FileInputStream;
+import java.io.FileReader;
+import java.io.IOException;
+import java.sql.Connection;
+import java.sql.DriverManager;
+import java.sql.ResultSet;
+import java.sql.SQLException;
+import java.sql.Statement;
+
+public class OrderRepository {
+    private final String url;
+
+    public OrderRepository(String url) {
+        this.url = url;
+    }
+
+    public int countOrders(String customerId) throws SQLException {
+        // BUG: connection is never closed, and the customer id is spliced into the SQL text
+        Connection conn = DriverManager.getConnection(url);
+        String sql = "SELECT COUNT(*) FROM orders WHERE customer_id = '" + customerId + "'";
+        try (Statement st = conn.createStatement(); ResultSet rs = st.executeQuery(sql)) {
+            return rs.next() ? rs.getInt(1) : 0;
+        }
+    }
+
+    public String firstLine(String path) throws IOException {
+        // BUG: reader opened inline and never closed
+        return new BufferedReader(new FileReader(path)).readLine();
+    }
+
+    public int firstByte(String path) throws IOException {
+        // BUG: stream leaks whenever read() throws or returns
+        FileInputStream in = new FileInputStream(path);
+        return in.read();
+    }
+
+    public void archive(String dir) throws IOException {
+        // BUG: a single command string is tokenized on spaces and dir is attacker-influenced
+        Runtime.getRuntime().exec("tar -czf /tmp/orders.tgz " + dir);
+    }
+
+    public void purge(String table) throws IOException {
+        // BUG: shell wrapper lets table smuggle extra commands
+        Runtime.getRuntime().exec(new String[] {"sh", "-c", "psql -c 'TRUNCATE " + table + "'"});
+    }
+
+    public void refresh() {
+        try {
+            countOrders("warmup");
+        } catch (Exception e) {
+            // BUG: every failure, including programming errors, silently disappears
+        }
+    }
+}
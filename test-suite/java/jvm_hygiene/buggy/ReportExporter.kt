@@ -0,0 +1,29 @@
+import java.io.File
+import java.sql.DriverManager
+
+class ReportExporter(private val url: String) {
+    fun export(region: String): Int {
+        // BUG: connection never closed; region interpolated into SQL via a string template
+        val conn = DriverManager.getConnection(url)
+        val rs = conn.createStatement().executeQuery("SELECT id FROM reports WHERE region = '$region'")
+        return if (rs.next()) rs.getInt(1) else 0
+    }
+
+    fun header(path: String): String? {
+        // BUG: reader is opened and never closed
+        return File(path).bufferedReader().readLine()
+    }
+
+    fun compress(dir: String) {
+        // BUG: Runtime.exec with an interpolated command string
+        Runtime.getRuntime().exec("zip -r /tmp/reports.zip $dir")
+    }
+
+    fun safeExport(region: String): Int =
+        try {
+            export(region)
+        } catch (e: Throwable) {
+            // BUG: swallowed; callers cannot tell a failure from zero rows
+            0
+        }
+}
@@ -0,0 +1,72 @@
+import java.io.BufferedReader;
+import java.io.FileInputStream;
+import java.io.FileReader;
+import java.io.IOException;
+import java.io.InputStream;
+import java.sql.Connection;
+import java.sql.DriverManager;
+import java.sql.PreparedStatement;
+import java.sql.ResultSet;
+import java.sql.SQLException;
+import java.util.logging.Level;
+import java.util.logging.Logger;
+
+public class OrderRepository {
+    private static final Logger LOG = Logger.getLogger(OrderRepository.class.getName());
+    private final String url;
+
+    public OrderRepository(String url) {
+        this.url = url;
+    }
+
+    public int countOrders(String customerId) throws SQLException {
+        String sql = "SELECT COUNT(*) FROM orders WHERE customer_id = ?";
+        try (Connection conn = DriverManager.getConnection(url);
+             PreparedStatement st = conn.prepareStatement(sql)) {
+            st.setString(1, customerId);
+            try (ResultSet rs = st.executeQuery()) {
+                return rs.next() ? rs.getInt(1) : 0;
+            }
+        }
+    }
+
+    public String firstLine(String path) throws IOException {
+        try (BufferedReader reader = new BufferedReader(new FileReader(path))) {
+            return reader.readLine();
+        }
+    }
+
+    public int firstByte(String path) throws IOException {
+        InputStream in = new FileInputStream(path);
+        try {
+            return in.read();
+        } finally {
+            in.close();
+        }
+    }
+
+    public InputStream open(String path) throws IOException {
+        // Ownership passes to the caller.
+        return new FileInputStream(path);
+    }
+
+    public Process archive(String dir) throws IOException {
+        return new ProcessBuilder("tar", "-czf", "/tmp/orders.tgz", "--", dir).start();
+    }
+
+    public void refresh() {
+        try {
+            countOrders("warmup");
+        } catch (SQLException e) {
+            LOG.log(Level.WARNING, "warmup query failed", e);
+        }
+    }
+
+    public void bestEffortCleanup(Runnable cleanup) {
+        try {
+            cleanup.run();
+        } catch (RuntimeException ignored) {
+            // Cleanup is best-effort; the primary operation already succeeded.
+        }
+    }
+}
@@ -0,0 +1,25 @@
+import java.io.File
+import java.sql.DriverManager
+import java.sql.SQLException
+
+class ReportExporter(private val url: String) {
+    fun export(region: String): Int =
+        DriverManager.getConnection(url).use { conn ->
+            conn.prepareStatement("SELECT id FROM reports WHERE region = ?").use { st ->
+                st.setString(1, region)
+                st.executeQuery().use { rs -> if (rs.next()) rs.getInt(1) else 0 }
+            }
+        }
+
+    fun header(path: String): String? = File(path).bufferedReader().use { it.readLine() }
+
+    fun compress(dir: String): Process =
+        ProcessBuilder(listOf("zip", "-r", "/tmp/reports.zip", dir)).start()
+
+    fun safeExport(region: String): Int =
+        try {
+            export(region)
+        } catch (e: SQLException) {
+            throw IllegalStateException("export failed for $region", e)
+        }
+}
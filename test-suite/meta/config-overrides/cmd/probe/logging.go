@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+// The logger in the context is an info-level finding; the blanket cmd/
+// severity caps findings at warning but never raises them, so it stays info.
+func withLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+func loggerFrom(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,8 @@
+package core
+
+var handlers = map[string]func(){}
+
+// Register mutates a package-level map without a lock; still reported here.
+func Register(name string, fn func()) {
+	handlers[name] = fn
+}
@@ -0,0 +1,9 @@
+package generated
+
+var handlers = map[string]func(){}
+
+// Register mutates a package-level map without a lock; silenced by the
+// **/generated/** override, which matches at any depth.
+func Register(name string, fn func()) {
+	handlers[name] = fn
+}
@@ -0,0 +1,10 @@
+package experimental
+
+var handlers = map[string]func(){}
+
+// Register mutates a package-level map without a lock. Override paths are
+// anchored at the project root, so internal/experimental/** does not reach
+// this nested copy and it is still reported.
+func Register(name string, fn func()) {
+	handlers[name] = fn
+}
@@ -0,0 +1,3 @@
+module example.com/fpbaseline
+
+go 1.22
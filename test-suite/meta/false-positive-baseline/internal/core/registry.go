@@ -0,0 +1,10 @@
+package core
+
+var handlers = map[string]func(){}
+
+// Register is only called from init, before any goroutine starts; the
+// .ubs-baseline.json entry marks the unsynchronized-mutation report as a
+// false positive, so it is hidden even though the line moved.
+func Register(name string, fn func()) {
+	handlers[name] = fn
+}
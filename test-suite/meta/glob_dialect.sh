@@ -0,0 +1,45 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# .ubscan.yaml override paths (modules/ubs-golang.sh) and policy `matches`
+# (ubs) must read the same path strings the same way. Each script carries its
+# own copy of glob_regex/path_matches; this fails when the copies drift apart
+# and then checks the anchoring rules on the shared code.
+# --ci (added by the manifest runner to every command) is dropped.
+
+repo="${*: -1}"
+repo="$(cd "$repo" && pwd -P)"
+
+extract() {
+  sed -n '/^def glob_regex(pattern):$/,/^    return re.fullmatch(glob_regex/p' "$1"
+}
+
+ubs_copy="$(extract "$repo/ubs")"
+module_copy="$(extract "$repo/modules/ubs-golang.sh")"
+if [[ -z "$ubs_copy" || "$ubs_copy" != "$module_copy" ]]; then
+  echo "glob dialect differs between ubs and modules/ubs-golang.sh:"
+  diff <(printf '%s\n' "$ubs_copy") <(printf '%s\n' "$module_copy") || true
+  exit 1
+fi
+echo "glob dialect: ubs and modules/ubs-golang.sh are identical"
+
+python3 -c "import re
+$ubs_copy
+
+cases = [
+    ('internal', 'internal/x.go', True),
+    ('internal', 'plugins/internal/x.go', False),
+    ('cmd/*.go', 'cmd/a.go', True),
+    ('cmd/*.go', 'cmd/a/b.go', False),
+    ('*.pb.go', 'x.pb.go', True),
+    ('*.pb.go', 'api/v1/x.pb.go', False),
+    ('**/*.pb.go', 'api/v1/x.pb.go', True),
+    ('cmd/**', 'cmd', True),
+    ('./legacy/', 'legacy/main.go', True),
+]
+failed = [c for c in cases if path_matches(c[0], c[1]) != c[2]]
+for pattern, path, want in failed:
+    print(f'path_matches({pattern!r}, {path!r}) != {want}')
+print(f'glob dialect: {len(cases) - len(failed)}/{len(cases)} anchoring checks passed')
+raise SystemExit(1 if failed else 0)
+"
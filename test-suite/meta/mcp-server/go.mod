@@ -0,0 +1,3 @@
+module example.com/mcpserver
+
+go 1.21
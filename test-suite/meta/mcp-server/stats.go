@@ -0,0 +1,16 @@
+package stats
+
+import "sync/atomic"
+
+type Counter struct {
+	hits int64
+}
+
+func (c *Counter) Add() {
+	atomic.AddInt64(&c.hits, 1)
+}
+
+// Plain read of a field every other path updates atomically.
+func (c *Counter) Value() int64 {
+	return c.hits
+}
@@ -0,0 +1,27 @@
+package export
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// Dump never closes the file it creates.
+func Dump(path string, data []byte) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// Poll leaves its context timer running on the error path.
+func Poll(ctx context.Context, check func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	if err := check(ctx); err != nil {
+		return err
+	}
+	cancel()
+	return nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// cancel is skipped on the error path.
+func probe(ctx context.Context, ping func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	if err := ping(ctx); err != nil {
+		return err
+	}
+	cancel()
+	return nil
+}
+
+func main() {
+	_ = probe(context.Background(), func(context.Context) error { return errors.New("down") })
+}
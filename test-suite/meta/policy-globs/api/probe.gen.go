@@ -0,0 +1,16 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// cancel is skipped on the error path.
+func probe(ctx context.Context, ping func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	if err := ping(ctx); err != nil {
+		return err
+	}
+	cancel()
+	return nil
+}
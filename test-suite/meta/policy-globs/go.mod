@@ -0,0 +1,3 @@
+module example.com/policyglobs
+
+go 1.22
@@ -0,0 +1,8 @@
+package service
+
+var handlers = map[string]func(){}
+
+// Register mutates a package-level map without a lock.
+func Register(name string, fn func()) {
+	handlers[name] = fn
+}
@@ -0,0 +1,22 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Scaffolds a rule with `ubs rules new` in a throwaway copy of the checkout
+# given as the last argument, then runs the golden cases it generated there.
+# The starter analyzer and fixtures must pass as scaffolded, so a change that
+# breaks the generator (or the module layout it edits) fails this case.
+# --ci (added by the manifest runner to every command) is dropped.
+
+repo="${*: -1}"
+repo="$(cd "$repo" && pwd -P)"
+work="$(mktemp -d)"
+trap 'rm -rf "$work"' EXIT
+
+# The runner checks every case path, so the whole suite comes along.
+cp -R "$repo/ubs" "$repo/install.sh" "$repo/SHA256SUMS" "$repo/modules" "$repo/scripts" "$repo/test-suite" "$work/"
+rm -rf "$work/test-suite/artifacts"
+
+"$work/ubs" rules new --repo="$work" --lang=go --id=go.scaffold.example-call \
+  --category=1 --title="exampleViolation called in a loop"
+python3 "$work/test-suite/run_manifest.py" \
+  --case golang-rule-scaffold-example-call-buggy --case golang-rule-scaffold-example-call-clean
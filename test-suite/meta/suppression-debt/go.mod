@@ -0,0 +1,3 @@
+module example.com/debt
+
+go 1.22
@@ -0,0 +1,8 @@
+package cache
+
+var entries = map[string]string{}
+
+// Put was suppressed until the end of 2025; the marker has expired.
+func Put(key, value string) {
+	entries[key] = value // ubs:ignore go.global.unsynchronized-mutation until=2025-12-31 ticket=PROJ-123 -- callers hold cacheMu
+}
@@ -0,0 +1,8 @@
+package legacy
+
+var handlers = map[string]func(){}
+
+// Register mutates a package-level map without a lock; the config exemption has expired.
+func Register(name string, fn func()) {
+	handlers[name] = fn
+}
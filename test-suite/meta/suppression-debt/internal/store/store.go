@@ -0,0 +1,8 @@
+package store
+
+var rows = map[int]string{}
+
+// Save stays suppressed until the migration deadline.
+func Save(id int, row string) {
+	rows[id] = row // ubs:ignore until=2099-12-31 ticket=PROJ-9 -- single writer during migration
+}
@@ -27,6 +27,13 @@ EXPECT_SUBSTRING_KEYS = (
     "require_substrings_stderr",
     "forbid_substrings_stderr",
 )
+# A trailing `// want "regex"` (or `# want "regex"`) marks a line a rule must report.
+WANT_RE = re.compile(r'(?://|#)\s*want\s+"((?:\\.|[^"\\])*)"\s*$')
+ANSI_RE = re.compile(r"\x1b\[[0-9;]*m")
+WANT_SUFFIXES = {
+    ".go", ".py", ".js", ".ts", ".rs", ".java", ".rb", ".swift", ".cs", ".ex", ".exs",
+    ".c", ".cc", ".cpp", ".h", ".hpp", ".sql", ".proto", ".yml", ".yaml",
+}
 
 
 def load_manifest(path: Path) -> Dict[str, Any]:
@@ -172,7 +179,7 @@ def expect_schema_errors(expect: Any, label: str) -> List[str]:
         if key in expect:
             errors.extend(string_list_errors(expect[key], f"{label}.expect.{key}"))
 
-    for key in ("allow_unparseable_output", "allow_zero_files"):
+    for key in ("allow_unparseable_output", "allow_zero_files", "want_annotations"):
         if key in expect and type(expect[key]) is not bool:
             errors.append(f"{label}.expect.{key} must be a boolean")
 
@@ -222,12 +229,16 @@ def manifest_schema_errors(manifest: Dict[str, Any]) -> List[str]:
             errors.append(f"{label}.enabled must be a boolean")
         if "ubs_bin" in case and not nonempty_string(case["ubs_bin"]):
             errors.append(f"{label}.ubs_bin must be a non-empty string")
+        if "subcommand" in case:
+            errors.extend(string_list_errors(case["subcommand"], f"{label}.subcommand"))
         if "args" in case:
             errors.extend(string_list_errors(case["args"], f"{label}.args"))
         if "env" in case:
             errors.extend(env_mapping_errors(case["env"], f"{label}.env"))
         if "bin_shims" in case:
             errors.extend(shim_mapping_errors(case["bin_shims"], f"{label}.bin_shims"))
+        if "stdin" in case and not isinstance(case["stdin"], str):
+            errors.append(f"{label}.stdin must be a string")
         errors.extend(expect_schema_errors(case.get("expect"), label))
 
     return errors
@@ -449,6 +460,50 @@ def check_expectations(
     return errors
 
 
+def want_annotations(case_path: Path) -> List[tuple[str, int, str]]:
+    """Collect (relative file, line, pattern) for every want comment under case_path."""
+    base = case_path if case_path.is_dir() else case_path.parent
+    files = [case_path] if case_path.is_file() else sorted(case_path.rglob("*"))
+    wants: List[tuple[str, int, str]] = []
+    for path in files:
+        if not path.is_file() or path.suffix.lower() not in WANT_SUFFIXES:
+            continue
+        try:
+            lines = path.read_text(encoding="utf-8", errors="ignore").splitlines()
+        except OSError:
+            continue
+        rel = path.relative_to(base).as_posix()
+        for lineno, text in enumerate(lines, 1):
+            match = WANT_RE.search(text)
+            if match:
+                wants.append((rel, lineno, match.group(1).replace('\\"', '"')))
+    return wants
+
+
+def want_annotation_errors(case_path: Path, stdout: str) -> List[str]:
+    """Every want comment needs an output line naming file:line whose finding
+    text (that line or the two before it) matches the want regex."""
+    wants = want_annotations(case_path)
+    if not wants:
+        return ["want_annotations set but no // want comments found"]
+    lines = ANSI_RE.sub("", stdout).splitlines()
+    errors: List[str] = []
+    for rel, lineno, pattern in wants:
+        try:
+            want = re.compile(pattern)
+        except re.error as exc:
+            errors.append(f"{rel}:{lineno}: bad want pattern {pattern!r}: {exc}")
+            continue
+        location = re.compile(rf"(?<![\w/.-]){re.escape(rel)}:{lineno}(?!\d)")
+        found = any(
+            location.search(line) and any(want.search(ctx) for ctx in lines[max(0, idx - 2):idx + 1])
+            for idx, line in enumerate(lines)
+        )
+        if not found:
+            errors.append(f"{rel}:{lineno}: no finding matching {pattern!r}")
+    return errors
+
+
 def format_case_result(case_id: str, status: str, duration: float, details: Sequence[str]) -> str:
     header = f"[{case_id}] {status.upper()} ({duration:.2f}s)"
     if not details:
@@ -548,7 +603,8 @@ def main() -> None:
         case_args = case.get("args", [])
         case_ubs_bin = case.get("ubs_bin")
         case_ubs_path = resolve_path(manifest_dir, case_ubs_bin) if case_ubs_bin else ubs_path
-        cmd = [str(case_ubs_path), *default_args, *case_args, case_path_arg]
+        # Subcommands (e.g. `debt`) must precede the default scan options.
+        cmd = [str(case_ubs_path), *case.get("subcommand", []), *default_args, *case_args, case_path_arg]
         env = os.environ.copy()
         env.update(default_env)
         env.update({k: str(v) for k, v in (case.get("env", {}) or {}).items()})
@@ -580,6 +636,7 @@ def main() -> None:
                 cmd,
                 cwd=REPO_ROOT,
                 text=True,
+                input=case.get("stdin"),
                 capture_output=True,
                 env=env,
                 timeout=args.case_timeout if args.case_timeout > 0 else None,
@@ -643,6 +700,8 @@ def main() -> None:
             proc.stderr,
             fail_on_warning,
         )
+        if (case.get("expect") or {}).get("want_annotations"):
+            errors.extend(want_annotation_errors(case_path_abs, proc.stdout))
         status = "pass"
         if summary_error:
             errors.append(summary_error)
@@ -0,0 +1,51 @@
+use std::collections::HashMap;
+use std::io::Read;
+use std::sync::{Mutex, RwLock};
+
+pub struct Registry {
+    entries: Mutex<HashMap<String, u64>>,
+    config: RwLock<String>,
+}
+
+impl Registry {
+    pub fn lookup(&self, key: &str) -> u64 {
+        // BUG: panics on a poisoned lock or a missing key in production code
+        let entries = self.entries.lock().unwrap();
+        *entries.get(key).expect("key registered at startup")
+    }
+
+    pub fn freeze(&self) {
+        // BUG: the guard never drops, so every later lock() deadlocks
+        let guard = self.entries.lock().unwrap();
+        std::mem::forget(guard);
+    }
+
+    pub fn pin_config(&self) {
+        // BUG: forgetting a read guard leaves the RwLock read-locked forever
+        std::mem::forget(self.config.read());
+    }
+}
+
+pub fn first_byte(bytes: &[u8]) -> u8 {
+    // BUG: no justification for why the index is in bounds
+    unsafe { *bytes.get_unchecked(0) }
+}
+
+pub struct RawHandle(*mut u8);
+
+// BUG: unsafe impl with no argument for thread safety
+unsafe impl Send for RawHandle {}
+
+pub async fn fetch_status(addr: &str) -> std::io::Result<usize> {
+    // BUG: blocking std::net socket inside an async fn stalls the executor thread
+    let mut stream = std::net::TcpStream::connect(addr)?;
+    let mut buf = Vec::new();
+    stream.read_to_end(&mut buf)?;
+    Ok(buf.len())
+}
+
+pub async fn git_revision() -> std::io::Result<Vec<u8>> {
+    // BUG: std::process::Command blocks the runtime worker until git exits
+    let out = std::process::Command::new("git").arg("rev-parse").arg("HEAD").output()?;
+    Ok(out.stdout)
+}
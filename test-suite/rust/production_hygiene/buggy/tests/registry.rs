@@ -0,0 +1,7 @@
+use production_hygiene_buggy::first_byte;
+
+#[test]
+fn first_byte_of_slice() {
+    // unwrap/expect in integration tests is expected and must not be reported
+    assert_eq!(Some(first_byte(b"ubs")).expect("value"), b'u');
+}
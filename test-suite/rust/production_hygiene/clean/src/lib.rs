@@ -0,0 +1,78 @@
+use std::collections::HashMap;
+use std::sync::{Mutex, RwLock};
+
+pub struct Registry {
+    entries: Mutex<HashMap<String, u64>>,
+    config: RwLock<String>,
+}
+
+#[derive(Debug)]
+pub enum RegistryError {
+    Poisoned,
+    Missing(String),
+}
+
+impl Registry {
+    pub fn lookup(&self, key: &str) -> Result<u64, RegistryError> {
+        let entries = self.entries.lock().map_err(|_| RegistryError::Poisoned)?;
+        entries
+            .get(key)
+            .copied()
+            .ok_or_else(|| RegistryError::Missing(key.to_string()))
+    }
+
+    pub fn config_len(&self) -> Result<usize, RegistryError> {
+        let config = self.config.read().map_err(|_| RegistryError::Poisoned)?;
+        Ok(config.len())
+    }
+}
+
+pub fn first_byte(bytes: &[u8]) -> Option<u8> {
+    if bytes.is_empty() {
+        return None;
+    }
+    // SAFETY: `bytes` is non-empty, checked immediately above.
+    Some(unsafe { std::ptr::read(bytes.as_ptr()) })
+}
+
+/// # Safety
+/// Implementors must be valid when every byte is zero.
+pub unsafe trait Zeroable {}
+
+#[repr(C)]
+pub struct Pixel {
+    r: u8,
+    g: u8,
+    b: u8,
+}
+
+// SAFETY: Pixel is repr(C) with only u8 fields, so the all-zero pattern is valid.
+unsafe impl Zeroable for Pixel {}
+
+pub async fn fetch_status(addr: &str) -> std::io::Result<usize> {
+    use tokio::io::AsyncReadExt;
+    let mut stream = tokio::net::TcpStream::connect(addr).await?;
+    let mut buf = Vec::new();
+    stream.read_to_end(&mut buf).await?;
+    Ok(buf.len())
+}
+
+pub async fn git_revision() -> std::io::Result<Vec<u8>> {
+    let out = tokio::process::Command::new("git").arg("rev-parse").arg("HEAD").output().await?;
+    Ok(out.stdout)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn lookup_missing_key() {
+        let registry = Registry {
+            entries: Mutex::new(HashMap::new()),
+            config: RwLock::new(String::new()),
+        };
+        assert!(registry.lookup("absent").is_err());
+        assert_eq!(registry.config_len().unwrap(), 0);
+    }
+}
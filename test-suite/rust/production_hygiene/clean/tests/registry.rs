@@ -0,0 +1,7 @@
+use production_hygiene_clean::first_byte;
+
+#[test]
+fn first_byte_of_slice() {
+    let value = first_byte(b"ubs").expect("non-empty input");
+    assert_eq!(value, b'u');
+}
@@ -0,0 +1,8 @@
+-- One-off backfill run by hand after the pricing change.
+UPDATE products SET price_cents = price_cents * 100;
+
+DELETE FROM cart_items;
+
+UPDATE orders
+   SET status = 'archived'
+  FROM customers;
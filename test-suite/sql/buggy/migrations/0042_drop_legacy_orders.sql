@@ -0,0 +1,15 @@
+-- +goose Up
+-- Retire the legacy order tables and rebuild the lookup index.
+DROP TABLE legacy_orders;
+
+ALTER TABLE customers DROP COLUMN fax_number;
+
+TRUNCATE TABLE order_audit;
+
+CREATE INDEX idx_orders_customer_id ON orders (customer_id);
+
+CREATE UNIQUE INDEX idx_payments_reference
+    ON payments (reference);
+
+-- +goose Down
+CREATE TABLE legacy_orders (id bigint PRIMARY KEY);
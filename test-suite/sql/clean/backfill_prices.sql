@@ -0,0 +1,13 @@
+-- One-off backfill run by hand after the pricing change.
+UPDATE products SET price_cents = price_cents * 100 WHERE price_cents < 1000;
+
+DELETE FROM cart_items WHERE updated_at < now() - interval '30 days';
+
+UPDATE orders
+   SET status = 'archived'
+  FROM customers
+ WHERE orders.customer_id = customers.id
+   AND customers.closed_at IS NOT NULL;
+
+-- Literal text that only looks like SQL must not be parsed as a statement.
+INSERT INTO audit_log (message) VALUES ('DELETE FROM cart_items; UPDATE products SET x = 1;');
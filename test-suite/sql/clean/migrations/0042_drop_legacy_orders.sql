@@ -0,0 +1,31 @@
+-- +goose Up
+-- Retire the legacy order tables and rebuild the lookup index.
+DROP TABLE IF EXISTS legacy_orders;
+
+ALTER TABLE customers DROP COLUMN IF EXISTS fax_number;
+
+-- Staging table is created and thrown away inside this migration.
+CREATE TABLE order_audit_staging (id bigint, note text);
+INSERT INTO order_audit_staging SELECT id, note FROM order_audit WHERE archived;
+DELETE FROM order_audit_staging;
+TRUNCATE order_audit_staging;
+DROP TABLE order_audit_staging;
+
+-- +goose NO TRANSACTION
+CREATE INDEX CONCURRENTLY idx_orders_customer_id ON orders (customer_id);
+
+CREATE TABLE refunds (id bigint PRIMARY KEY, payment_id bigint NOT NULL);
+CREATE INDEX idx_refunds_payment_id ON refunds (payment_id);
+
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM pg_tables WHERE tablename = 'order_audit') THEN
+    TRUNCATE order_audit;
+  END IF;
+END
+$$;
+
+-- +goose Down
+DROP TABLE refunds;
+DROP INDEX CONCURRENTLY idx_orders_customer_id;
+CREATE TABLE legacy_orders (id bigint PRIMARY KEY);